@@ -17,5 +17,5 @@ provider "oracle" {
 resource "oracle_grant_directory_privileges" "test_grant" {
   principal  = "testuser"
   directory  = "testdir"
-  privileges = toset(["READ"])
+  privileges = [{ privilege = "READ" }]
 }
\ No newline at end of file
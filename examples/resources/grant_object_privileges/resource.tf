@@ -10,5 +10,5 @@ resource "oracle_grant_object_privileges" "test_grant" {
   principal  = "testuser"
   object     = "test_table"
   owner      = "test"
-  privileges = toset(["SELECT"])
+  privileges = [{ privilege = "SELECT" }]
 }
\ No newline at end of file
@@ -16,5 +16,5 @@ provider "oracle" {
 
 resource "oracle_grant_system_privileges" "test_grant" {
   principal  = "testuser"
-  privileges = toset(["CREATE SESSION"])
+  privileges = [{ privilege = "CREATE SESSION" }]
 }
\ No newline at end of file
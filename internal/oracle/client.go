@@ -6,6 +6,7 @@ package oracle
 import (
 	"database/sql"
 	"fmt"
+	"sync"
 
 	goOra "github.com/sijms/go-ora/v2"
 )
@@ -15,6 +16,18 @@ import (
 
 type Client struct {
 	DB *sql.DB
+
+	// LivePrivilegeCatalog reads the privilege names used to validate grants
+	// from the data dictionary instead of the embedded catalogs.
+	LivePrivilegeCatalog bool
+
+	versionOnce sync.Once
+	version     Version
+	versionErr  error
+
+	catalogOnce sync.Once
+	catalog     PrivilegeCatalog
+	catalogErr  error
 }
 
 // NewClient creates and returns a new Oracle client.
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// IsCDB checks if the database is a multitenant container database.
+//
+// Returns:
+//
+//	A boolean indicating whether the database is a CDB, and an error if the check fails.
+func (c *Client) IsCDB() (bool, error) {
+	var cdb string
+	err := c.DB.QueryRow("SELECT cdb FROM v$database").Scan(&cdb)
+	if err != nil {
+		return false, err
+	}
+	return cdb == "YES", nil
+}
+
+// CommonUserPrefix returns the value of the common_user_prefix parameter.
+//
+// Returns:
+//
+//	The prefix required for common user and role names (usually "C##"), and an error if the read fails.
+func (c *Client) CommonUserPrefix() (string, error) {
+	var prefix sql.NullString
+	err := c.DB.QueryRow("SELECT value FROM v$parameter WHERE name = 'common_user_prefix'").Scan(&prefix)
+	if err != nil {
+		return "", err
+	}
+	return prefix.String, nil
+}
+
+// ValidateContainerName checks that a user or role name matches its container scope.
+// Common users and roles (container scope "all") must start with the common_user_prefix,
+// while local users and roles in a CDB must not.
+//
+// Parameters:
+//
+//	name: The name of the user or role.
+//	containerScope: The container scope, either "current" or "all".
+//
+// Returns:
+//
+//	An error if the name is not valid for the container scope or if the check fails.
+func (c *Client) ValidateContainerName(name, containerScope string) error {
+	cdb, err := c.IsCDB()
+	if err != nil {
+		return err
+	}
+	if !cdb {
+		if containerScope == "all" {
+			return fmt.Errorf("container scope \"all\" requires a multitenant container database")
+		}
+		return nil
+	}
+
+	prefix, err := c.CommonUserPrefix()
+	if err != nil {
+		return err
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	common := strings.HasPrefix(strings.ToUpper(name), strings.ToUpper(prefix))
+	if containerScope == "all" && !common {
+		return fmt.Errorf("common user and role names must start with %q, got %q", prefix, name)
+	}
+	if containerScope != "all" && common {
+		return fmt.Errorf("local user and role names must not start with %q, got %q", prefix, name)
+	}
+	return nil
+}
+
+// containerClause returns the CONTAINER clause for a container scope.
+// The current container is Oracle's default, so no clause is emitted for it.
+func containerClause(containerScope string) string {
+	if containerScope == "all" {
+		return " CONTAINER=ALL"
+	}
+	return ""
+}
+
+// containerFilter returns the dictionary predicate selecting grants made in a container scope.
+// Grants inherited from the root cannot be managed locally and are excluded.
+func containerFilter(containerScope string) string {
+	if containerScope == "all" {
+		return " AND common = 'YES' AND inherited = 'NO'"
+	}
+	return " AND common = 'NO'"
+}
+
+// scopeFromCommon maps the common column of a dictionary view to a container scope.
+func scopeFromCommon(common string) string {
+	if common == "YES" {
+		return "all"
+	}
+	return "current"
+}
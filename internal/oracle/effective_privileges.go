@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"strings"
+)
+
+// EffectivePrivileges holds every privilege a user or role can use, granted
+// either directly or through roles, including the roles granted to PUBLIC.
+type EffectivePrivileges struct {
+	SystemPrivileges []EffectiveSystemPrivilege // The system privileges that can be used.
+	ObjectPrivileges []EffectiveObjectPrivilege // The object privileges that can be used.
+	Roles            []EffectiveRole            // The roles that are granted directly or through other roles.
+}
+
+// EffectiveSystemPrivilege is a system privilege together with the grant path
+// through which it is held.
+type EffectiveSystemPrivilege struct {
+	Privilege   string   // The name of the privilege.
+	AdminOption bool     // Whether the privilege was granted WITH ADMIN OPTION.
+	Path        []string // The grantees from the principal to the grantee of the privilege, e.g. ["APP_USER", "ROLE_A"].
+}
+
+// EffectiveObjectPrivilege is an object or column privilege together with the
+// grant path through which it is held.
+type EffectiveObjectPrivilege struct {
+	Owner       string   // The owner of the object.
+	Object      string   // The name of the object.
+	Privilege   string   // The privilege granted on the object.
+	Columns     []string // The columns of a column privilege, or nil for a privilege on the whole object.
+	GrantOption bool     // Whether the privilege was granted WITH GRANT OPTION.
+	Path        []string // The grantees from the principal to the grantee of the privilege.
+}
+
+// EffectiveRole is a role together with the grant path through which it is held.
+type EffectiveRole struct {
+	Role        string   // The name of the role.
+	AdminOption bool     // Whether the role was granted WITH ADMIN OPTION.
+	Path        []string // The grantees from the principal to the grantee of the role.
+}
+
+// roleEdge is a role granted to a grantee, as read from dba_role_privs.
+type roleEdge struct {
+	role        string
+	adminOption bool
+}
+
+// GetEffectivePrivileges reads every privilege a user or role can use.
+//
+// The roles granted to the principal are walked recursively through
+// dba_role_privs. Every role is visited and returned once, through the
+// shortest grant path, so that roles granted through several paths or
+// circular grants do not loop. A privilege granted to several roles is returned once for each of them,
+// but only once per role even if it was granted by several grantors or in
+// several containers.
+//
+// Parameters:
+//
+//	principal: The user or role whose privileges should be read.
+//	includePublic: Whether to include the privileges and roles granted to PUBLIC.
+//
+// Returns:
+//
+//	An EffectivePrivileges struct with the privileges and their grant paths, and an error if a read fails.
+func (c *Client) GetEffectivePrivileges(principal string, includePublic bool) (*EffectivePrivileges, error) {
+	roots := []string{strings.ToUpper(principal)}
+	if includePublic && !IsPublic(principal) {
+		roots = append(roots, "PUBLIC")
+	}
+
+	grantees, roles, err := walkRoles(roots, c.roleEdges)
+	if err != nil {
+		return nil, err
+	}
+
+	privileges := &EffectivePrivileges{Roles: roles}
+	for _, path := range grantees {
+		systemPrivileges, err := c.effectiveSystemPrivileges(path)
+		if err != nil {
+			return nil, err
+		}
+		privileges.SystemPrivileges = append(privileges.SystemPrivileges, systemPrivileges...)
+
+		objectPrivileges, err := c.effectiveObjectPrivileges(path)
+		if err != nil {
+			return nil, err
+		}
+		privileges.ObjectPrivileges = append(privileges.ObjectPrivileges, objectPrivileges...)
+
+		columnPrivileges, err := c.effectiveColumnPrivileges(path)
+		if err != nil {
+			return nil, err
+		}
+		privileges.ObjectPrivileges = append(privileges.ObjectPrivileges, columnPrivileges...)
+	}
+	return privileges, nil
+}
+
+// effectiveSystemPrivileges reads the system privileges granted to the last
+// grantee of a grant path. dba_sys_privs returns a row per container for
+// common grants, so the rows are grouped by privilege and the admin option
+// is held if any of them holds it.
+func (c *Client) effectiveSystemPrivileges(path []string) ([]EffectiveSystemPrivilege, error) {
+	rows, err := c.DB.Query("SELECT privilege, MAX(admin_option) FROM dba_sys_privs WHERE grantee = :1"+
+		" GROUP BY privilege ORDER BY privilege", path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []EffectiveSystemPrivilege
+	for rows.Next() {
+		var privilege, adminOption string
+		if err := rows.Scan(&privilege, &adminOption); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, EffectiveSystemPrivilege{
+			Privilege:   privilege,
+			AdminOption: adminOption == "YES",
+			Path:        path,
+		})
+	}
+	return privileges, rows.Err()
+}
+
+// effectiveObjectPrivileges reads the object privileges granted to the last
+// grantee of a grant path. dba_tab_privs returns a row per grantor and
+// container, so the rows are grouped by object and privilege and the grant
+// option is held if any of them holds it.
+func (c *Client) effectiveObjectPrivileges(path []string) ([]EffectiveObjectPrivilege, error) {
+	rows, err := c.DB.Query("SELECT owner, table_name, privilege, MAX(grantable) FROM dba_tab_privs WHERE grantee = :1"+
+		" GROUP BY owner, table_name, privilege ORDER BY owner, table_name, privilege", path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []EffectiveObjectPrivilege
+	for rows.Next() {
+		var privilege EffectiveObjectPrivilege
+		var grantable string
+		if err := rows.Scan(&privilege.Owner, &privilege.Object, &privilege.Privilege, &grantable); err != nil {
+			return nil, err
+		}
+		privilege.GrantOption = grantable == "YES"
+		privilege.Path = path
+		privileges = append(privileges, privilege)
+	}
+	return privileges, rows.Err()
+}
+
+// effectiveColumnPrivileges reads the column privileges granted to the last
+// grantee of a grant path from dba_col_privs. The columns of a privilege on
+// an object are returned together, split by grant option.
+func (c *Client) effectiveColumnPrivileges(path []string) ([]EffectiveObjectPrivilege, error) {
+	rows, err := c.DB.Query("SELECT owner, table_name, privilege, MAX(grantable), column_name FROM dba_col_privs WHERE grantee = :1"+
+		" GROUP BY owner, table_name, privilege, column_name ORDER BY owner, table_name, privilege, 4, column_name", path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []EffectiveObjectPrivilege
+	for rows.Next() {
+		var privilege EffectiveObjectPrivilege
+		var grantable, column string
+		if err := rows.Scan(&privilege.Owner, &privilege.Object, &privilege.Privilege, &grantable, &column); err != nil {
+			return nil, err
+		}
+		privilege.GrantOption = grantable == "YES"
+		privilege.Path = path
+
+		if n := len(privileges); n > 0 {
+			last := &privileges[n-1]
+			if last.Owner == privilege.Owner && last.Object == privilege.Object &&
+				last.Privilege == privilege.Privilege && last.GrantOption == privilege.GrantOption {
+				last.Columns = append(last.Columns, column)
+				continue
+			}
+		}
+		privilege.Columns = []string{column}
+		privileges = append(privileges, privilege)
+	}
+	return privileges, rows.Err()
+}
+
+// roleEdges reads the roles granted directly to a grantee, once per role.
+func (c *Client) roleEdges(grantee string) ([]roleEdge, error) {
+	rows, err := c.DB.Query("SELECT granted_role, MAX(admin_option) FROM dba_role_privs WHERE grantee = :1"+
+		" GROUP BY granted_role ORDER BY granted_role", grantee)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edges []roleEdge
+	for rows.Next() {
+		var role, adminOption string
+		if err := rows.Scan(&role, &adminOption); err != nil {
+			return nil, err
+		}
+		edges = append(edges, roleEdge{role: role, adminOption: adminOption == "YES"})
+	}
+	return edges, rows.Err()
+}
+
+// walkRoles walks the roles granted to the roots breadth-first. It returns
+// the grant path of every grantee, starting with the roots, and the roles
+// granted to them. Every grantee is visited once, through the shortest path,
+// so that circular grants cannot loop, and every role is returned once, with
+// that path. Grants of a role that was already visited are left out.
+func walkRoles(roots []string, edges func(grantee string) ([]roleEdge, error)) ([][]string, []EffectiveRole, error) {
+	var grantees [][]string
+	var roles []EffectiveRole
+	visited := map[string]bool{}
+	for _, root := range roots {
+		visited[root] = true
+		grantees = append(grantees, []string{root})
+	}
+
+	for i := 0; i < len(grantees); i++ {
+		path := grantees[i]
+		granted, err := edges(path[len(path)-1])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, edge := range granted {
+			if visited[edge.role] {
+				continue
+			}
+			visited[edge.role] = true
+			roles = append(roles, EffectiveRole{Role: edge.role, AdminOption: edge.adminOption, Path: path})
+			grantees = append(grantees, append(append([]string{}, path...), edge.role))
+		}
+	}
+	return grantees, roles, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkRoles(t *testing.T) {
+	// ROLE_C is granted through two paths and ROLE_B is granted back to ROLE_A
+	graph := map[string][]roleEdge{
+		"APP_USER": {{role: "ROLE_A"}, {role: "ROLE_C"}},
+		"ROLE_A":   {{role: "ROLE_B", adminOption: true}},
+		"ROLE_B":   {{role: "ROLE_A"}, {role: "ROLE_C"}},
+		"PUBLIC":   {{role: "ROLE_D"}},
+	}
+	grantees, roles, err := walkRoles([]string{"APP_USER", "PUBLIC"}, func(grantee string) ([]roleEdge, error) {
+		return graph[grantee], nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"APP_USER"},
+		{"PUBLIC"},
+		{"APP_USER", "ROLE_A"},
+		{"APP_USER", "ROLE_C"},
+		{"PUBLIC", "ROLE_D"},
+		{"APP_USER", "ROLE_A", "ROLE_B"},
+	}, grantees)
+	assert.Equal(t, []EffectiveRole{
+		{Role: "ROLE_A", Path: []string{"APP_USER"}},
+		{Role: "ROLE_C", Path: []string{"APP_USER"}},
+		{Role: "ROLE_D", Path: []string{"PUBLIC"}},
+		{Role: "ROLE_B", AdminOption: true, Path: []string{"APP_USER", "ROLE_A"}},
+	}, roles)
+}
+
+func TestWalkRoles_CycleAndDiamond(t *testing.T) {
+	// ROLE_D is granted through ROLE_B and ROLE_C, and ROLE_D is granted back
+	// to ROLE_A, which is also granted back to the principal itself
+	graph := map[string][]roleEdge{
+		"APP_ROLE": {{role: "ROLE_A"}},
+		"ROLE_A":   {{role: "ROLE_B"}, {role: "ROLE_C", adminOption: true}},
+		"ROLE_B":   {{role: "ROLE_D"}},
+		"ROLE_C":   {{role: "ROLE_D", adminOption: true}},
+		"ROLE_D":   {{role: "ROLE_A"}, {role: "APP_ROLE"}},
+	}
+	grantees, roles, err := walkRoles([]string{"APP_ROLE"}, func(grantee string) ([]roleEdge, error) {
+		return graph[grantee], nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"APP_ROLE"},
+		{"APP_ROLE", "ROLE_A"},
+		{"APP_ROLE", "ROLE_A", "ROLE_B"},
+		{"APP_ROLE", "ROLE_A", "ROLE_C"},
+		{"APP_ROLE", "ROLE_A", "ROLE_B", "ROLE_D"},
+	}, grantees)
+	assert.Equal(t, []EffectiveRole{
+		{Role: "ROLE_A", Path: []string{"APP_ROLE"}},
+		{Role: "ROLE_B", Path: []string{"APP_ROLE", "ROLE_A"}},
+		{Role: "ROLE_C", AdminOption: true, Path: []string{"APP_ROLE", "ROLE_A"}},
+		{Role: "ROLE_D", Path: []string{"APP_ROLE", "ROLE_A", "ROLE_B"}},
+	}, roles)
+}
+
+func TestGetEffectivePrivileges(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	_, err = client.DB.Exec("CREATE USER test_effective_user IDENTIFIED BY MyPassword123")
+	assert.NoError(t, err)
+	defer client.DB.Exec("DROP USER test_effective_user")
+
+	for _, statement := range []string{
+		"CREATE ROLE test_effective_a",
+		"CREATE ROLE test_effective_b",
+		"GRANT test_effective_b TO test_effective_a",
+		"GRANT test_effective_a TO test_effective_user",
+		"GRANT CREATE SESSION TO test_effective_b",
+		"GRANT SELECT ON system.help TO test_effective_b WITH GRANT OPTION",
+		"GRANT UPDATE (info, topic) ON system.help TO test_effective_b",
+	} {
+		_, err = client.DB.Exec(statement)
+		assert.NoError(t, err)
+	}
+	defer client.DB.Exec("DROP ROLE test_effective_a")
+	defer client.DB.Exec("DROP ROLE test_effective_b")
+
+	privileges, err := client.GetEffectivePrivileges("test_effective_user", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []EffectiveRole{
+		{Role: "TEST_EFFECTIVE_A", Path: []string{"TEST_EFFECTIVE_USER"}},
+		{Role: "TEST_EFFECTIVE_B", Path: []string{"TEST_EFFECTIVE_USER", "TEST_EFFECTIVE_A"}},
+	}, privileges.Roles)
+	assert.Contains(t, privileges.SystemPrivileges, EffectiveSystemPrivilege{
+		Privilege: "CREATE SESSION",
+		Path:      []string{"TEST_EFFECTIVE_USER", "TEST_EFFECTIVE_A", "TEST_EFFECTIVE_B"},
+	})
+	assert.Contains(t, privileges.ObjectPrivileges, EffectiveObjectPrivilege{
+		Owner:       "SYSTEM",
+		Object:      "HELP",
+		Privilege:   "SELECT",
+		GrantOption: true,
+		Path:        []string{"TEST_EFFECTIVE_USER", "TEST_EFFECTIVE_A", "TEST_EFFECTIVE_B"},
+	})
+	assert.Contains(t, privileges.ObjectPrivileges, EffectiveObjectPrivilege{
+		Owner:     "SYSTEM",
+		Object:    "HELP",
+		Privilege: "UPDATE",
+		Columns:   []string{"INFO", "TOPIC"},
+		Path:      []string{"TEST_EFFECTIVE_USER", "TEST_EFFECTIVE_A", "TEST_EFFECTIVE_B"},
+	})
+
+	// Privileges granted to PUBLIC are only included on request
+	withPublic, err := client.GetEffectivePrivileges("test_effective_user", true)
+	assert.NoError(t, err)
+	assert.Greater(t, len(withPublic.ObjectPrivileges), len(privileges.ObjectPrivileges))
+}
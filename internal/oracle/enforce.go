@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IsIgnored checks if a privilege or role matches one of the ignore patterns
+// used by enforce mode. Patterns are case-insensitive and "*" matches any
+// sequence of characters, e.g. "UNLIMITED TABLESPACE" or "APEX_*". The
+// WITH ADMIN OPTION and WITH GRANT OPTION suffixes are not part of the match.
+//
+// Parameters:
+//
+//	patterns: The ignore patterns.
+//	privilege: The privilege or role to check.
+//
+// Returns:
+//
+//	True if the privilege matches at least one pattern.
+func IsIgnored(patterns []string, privilege string) bool {
+	name := strings.ToUpper(privilege)
+	name = strings.TrimSuffix(name, " WITH ADMIN OPTION")
+	name = strings.TrimSuffix(name, " WITH GRANT OPTION")
+	for _, pattern := range patterns {
+		if matchesPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern checks if a name matches a case-insensitive pattern in which
+// "*" matches any sequence of characters.
+func matchesPattern(pattern, name string) bool {
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(strings.ToUpper(pattern)), `\*`, ".*") + "$"
+	return regexp.MustCompile(expr).MatchString(strings.ToUpper(name))
+}
+
+// IsOracleMaintained checks if a user or role was created by Oracle-maintained scripts.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//
+// Returns:
+//
+//	True if the principal is Oracle-maintained, and an error if the check fails.
+func (c *Client) IsOracleMaintained(principal string) (bool, error) {
+	var count int
+	sql := `SELECT COUNT(*) FROM (
+		SELECT oracle_maintained FROM dba_users WHERE username = UPPER(:1)
+		UNION ALL
+		SELECT oracle_maintained FROM dba_roles WHERE role = UPPER(:1)
+	) WHERE oracle_maintained = 'Y'`
+	if err := c.DB.QueryRow(sql, principal).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// checkEnforcePrincipal refuses to enforce the grants of an Oracle-maintained
+// user or role unless allowOracleMaintained is set, as revoking their grants
+// can break the database. PUBLIC is allowed, as enforce mode only revokes the
+// grants the provider made to it.
+func (c *Client) checkEnforcePrincipal(principal string, allowOracleMaintained bool) error {
+	if allowOracleMaintained || IsPublic(principal) {
+		return nil
+	}
+	maintained, err := c.IsOracleMaintained(principal)
+	if err != nil {
+		return err
+	}
+	if maintained {
+		return fmt.Errorf("refusing to enforce grants of Oracle-maintained principal %s", strings.ToUpper(principal))
+	}
+	return nil
+}
+
+// PublicPrincipal is the name of PUBLIC, the role that every user holds.
+const PublicPrincipal = "PUBLIC"
+
+// IsPublic checks if a principal is PUBLIC.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//
+// Returns:
+//
+//	True if the principal is PUBLIC, ignoring case.
+func IsPublic(principal string) bool {
+	return strings.EqualFold(strings.TrimSpace(principal), PublicPrincipal)
+}
+
+// revocable restricts the privileges that enforce mode revokes from PUBLIC to
+// the managed ones, i.e. those granted by the provider. Oracle grants many
+// privileges to PUBLIC by default and the database depends on them. The
+// privileges of other principals are returned unchanged.
+func revocable(principal string, toRevoke []Privilege, managed []string) []Privilege {
+	if !IsPublic(principal) {
+		return toRevoke
+	}
+	privileges := []Privilege{}
+	for _, privilege := range toRevoke {
+		if ContainsFold(managed, privilege.Name) {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges
+}
+
+// revocableColumns restricts the column privileges that enforce mode revokes
+// from PUBLIC to the managed ones, like revocable.
+func revocableColumns(principal string, toRevoke map[string][]string, managed []string) map[string][]string {
+	if !IsPublic(principal) {
+		return toRevoke
+	}
+	privileges := map[string][]string{}
+	for privilege, columns := range toRevoke {
+		if ContainsFold(managed, privilege) {
+			privileges[privilege] = columns
+		}
+	}
+	return privileges
+}
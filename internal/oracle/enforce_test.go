@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestIsIgnored(t *testing.T) {
+	patterns := []string{"unlimited tablespace", "APEX_*", "*_CATALOG_ROLE"}
+
+	assert.True(t, oracle.IsIgnored(patterns, "UNLIMITED TABLESPACE"))
+	assert.True(t, oracle.IsIgnored(patterns, "UNLIMITED TABLESPACE WITH ADMIN OPTION"))
+	assert.True(t, oracle.IsIgnored(patterns, "apex_administrator_role"))
+	assert.True(t, oracle.IsIgnored(patterns, "SELECT_CATALOG_ROLE"))
+	assert.False(t, oracle.IsIgnored(patterns, "CREATE SESSION"))
+	assert.False(t, oracle.IsIgnored(patterns, "UNLIMITED TABLESPACES"))
+	assert.False(t, oracle.IsIgnored(nil, "UNLIMITED TABLESPACE"))
+	assert.False(t, oracle.IsIgnored([]string{"DBA."}, "DBAX"))
+}
+
+func TestIsPublic(t *testing.T) {
+	assert.True(t, oracle.IsPublic("PUBLIC"))
+	assert.True(t, oracle.IsPublic(" public "))
+	assert.False(t, oracle.IsPublic("PUBLIC_ROLE"))
+	assert.False(t, oracle.IsPublic(""))
+}
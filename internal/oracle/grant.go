@@ -10,26 +10,38 @@ import (
 
 // Grant represents a system privilege to be granted to a user or role.
 type Grant struct {
-	Principal  string   // The user or role to whom the privileges should be granted.
-	Privileges []string // A list of system privileges to grant.
-	GrantsMode string   // The grants mode, either "enforce" or "append".
+	Principal             string      // The user or role to whom the privileges should be granted.
+	Privileges            []Privilege // A list of system privileges to grant.
+	GrantsMode            string      // The grants mode, either "enforce" or "append".
+	ContainerScope        string      // The container scope, either "current" or "all" for common grants in a CDB.
+	Ignore                []string    // Patterns of privileges that enforce mode does not revoke.
+	AllowOracleMaintained bool        // Whether enforce mode may revoke privileges of Oracle-maintained principals.
+	Managed               []string    // For PUBLIC, the privileges that enforce mode may revoke, i.e. those granted by the provider.
 }
 
 // ObjectPrivilege represents a privilege on a specific database object.
 type ObjectPrivilege struct {
-	Principal  string   // The user or role to whom the privileges should be granted.
-	Object     string   // The database object on which to grant the privileges.
-	Owner      string   // The owner of the database object.
-	Privileges []string // A list of object privileges to grant.
-	GrantsMode string   // The grants mode, either "enforce" or "append".
+	Principal             string              // The user or role to whom the privileges should be granted.
+	Object                string              // The database object on which to grant the privileges.
+	Owner                 string              // The owner of the database object.
+	ObjectType            string              // The type of the database object, e.g. "TABLE", "EDITION" or "JAVA SOURCE", or an empty string for any type.
+	Privileges            []Privilege         // A list of object privileges to grant.
+	ColumnPrivileges      map[string][]string // Column-level privileges to grant, mapping INSERT, UPDATE or REFERENCES to a list of columns.
+	GrantsMode            string              // The grants mode, either "enforce" or "append".
+	ContainerScope        string              // The container scope, either "current" or "all" for common grants in a CDB.
+	AllowOracleMaintained bool                // Whether enforce mode may revoke privileges of Oracle-maintained principals.
+	Managed               []string            // For PUBLIC, the privileges and column privileges that enforce mode may revoke, i.e. those granted by the provider.
 }
 
 // DirectoryPrivilege represents a privilege on a specific database directory.
 type DirectoryPrivilege struct {
-	Principal  string   // The user or role to whom the privileges should be granted.
-	Directory  string   // The database directory on which to grant the privileges.
-	Privileges []string // A list of directory privileges to grant.
-	GrantsMode string   // The grants mode, either "enforce" or "append".
+	Principal             string      // The user or role to whom the privileges should be granted.
+	Directory             string      // The database directory on which to grant the privileges.
+	Privileges            []Privilege // A list of directory privileges to grant.
+	GrantsMode            string      // The grants mode, either "enforce" or "append".
+	ContainerScope        string      // The container scope, either "current" or "all" for common grants in a CDB.
+	AllowOracleMaintained bool        // Whether enforce mode may revoke privileges of Oracle-maintained principals.
+	Managed               []string    // For PUBLIC, the privileges that enforce mode may revoke, i.e. those granted by the provider.
 }
 
 // GrantSystemPrivileges grants system privileges to a user or role.
@@ -43,41 +55,51 @@ type DirectoryPrivilege struct {
 //	An error if the grant operation fails.
 func (c *Client) GrantSystemPrivileges(grant Grant) error {
 	if grant.GrantsMode == "enforce" {
-		currentPrivs, err := c.GetCurrentSystemPrivileges(grant.Principal)
+		if err := c.checkEnforcePrincipal(grant.Principal, grant.AllowOracleMaintained); err != nil {
+			return err
+		}
+
+		currentPrivs, err := c.GetCurrentSystemPrivileges(grant.Principal, grant.ContainerScope)
 		if err != nil {
 			return err
 		}
 
-		// Revoke privileges that are not in the desired list
-		for _, currentPriv := range currentPrivs {
-			found := false
-			for _, desiredPriv := range grant.Privileges {
-				if strings.EqualFold(currentPriv, desiredPriv) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				revokeSQL := fmt.Sprintf("REVOKE %s FROM %s", currentPriv, grant.Principal)
-				if _, err := c.DB.Exec(revokeSQL); err != nil {
-					return err
-				}
+		// Revoke privileges that are not in the desired list, or that hold an
+		// admin option that is no longer desired
+		_, toRevoke := diffPrivileges(currentPrivs, grant.Privileges, true, grant.Ignore)
+		for _, priv := range revocable(grant.Principal, toRevoke, grant.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s FROM %s%s", priv.Name, grant.Principal, containerClause(grant.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
 			}
 		}
 	}
 
-	// Grant the desired privileges
-	if len(grant.Privileges) > 0 {
-		privs := strings.Join(grant.Privileges, ",")
-		grantSQL := fmt.Sprintf("GRANT %s TO %s", privs, grant.Principal)
-		_, err := c.DB.Exec(grantSQL)
-		return err
+	// Grant the desired privileges, in one statement per admin option
+	for _, adminOption := range []bool{false, true} {
+		var privs []string
+		for _, priv := range grant.Privileges {
+			if priv.AdminOption == adminOption {
+				privs = append(privs, priv.Name)
+			}
+		}
+		if len(privs) == 0 {
+			continue
+		}
+		grantSQL := fmt.Sprintf("GRANT %s TO %s%s%s", strings.Join(privs, ","), grant.Principal, Privilege{AdminOption: adminOption}.options(), containerClause(grant.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // GrantObjectPrivileges grants object privileges to a user or role.
 //
+// Oracle can only revoke a column-level privilege from all columns at once, so
+// in enforce mode a privilege that should be removed from some of its columns
+// is revoked and then granted again on the remaining columns.
+//
 // Parameters:
 //
 //	privilege: An ObjectPrivilege struct containing the details of the privileges to be granted.
@@ -86,45 +108,164 @@ func (c *Client) GrantSystemPrivileges(grant Grant) error {
 //
 //	An error if the grant operation fails.
 func (c *Client) GrantObjectPrivileges(privilege ObjectPrivilege) error {
-	object := fmt.Sprintf("%s.%s", privilege.Owner, privilege.Object)
-	if privilege.Owner == "" {
-		object = privilege.Object
-	}
+	object := objectClause(privilege)
 	if privilege.GrantsMode == "enforce" {
-		currentPrivs, err := c.GetCurrentObjectPrivileges(privilege.Principal, privilege.Owner, privilege.Object)
+		if err := c.checkEnforcePrincipal(privilege.Principal, privilege.AllowOracleMaintained); err != nil {
+			return err
+		}
+
+		currentPrivs, err := c.GetCurrentObjectPrivileges(privilege.Principal, privilege.Owner, privilege.Object, privilege.ObjectType, privilege.ContainerScope)
 		if err != nil {
 			return err
 		}
 
-		// Revoke privileges that are not in the desired list
-		for _, currentPriv := range currentPrivs {
-			found := false
-			for _, desiredPriv := range privilege.Privileges {
-				if strings.EqualFold(currentPriv, desiredPriv) {
-					found = true
-					break
-				}
+		// Revoke privileges that are not in the desired list, or that hold a
+		// grant or hierarchy option that is no longer desired
+		_, toRevoke := diffPrivileges(currentPrivs, privilege.Privileges, true, nil)
+		for _, priv := range revocable(privilege.Principal, toRevoke, privilege.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON %s FROM %s%s", priv.Name, object, privilege.Principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
 			}
-			if !found {
-				revokeSQL := fmt.Sprintf("REVOKE %s ON %s FROM %s", currentPriv, object, privilege.Principal)
-				if _, err := c.DB.Exec(revokeSQL); err != nil {
-					return err
-				}
+		}
+
+		currentColumnPrivs, err := c.GetCurrentColumnPrivileges(privilege.Principal, privilege.Owner, privilege.Object, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		// Revoke column privileges that are granted on columns not in the desired list
+		_, columnsToRevoke := diffColumnPrivileges(currentColumnPrivs, privilege.ColumnPrivileges, true)
+		for priv := range revocableColumns(privilege.Principal, columnsToRevoke, privilege.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON %s FROM %s%s", priv, object, privilege.Principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
 			}
 		}
 	}
 
 	// Grant the desired privileges
-	if len(privilege.Privileges) > 0 {
-		for _, priv := range privilege.Privileges {
-			grantSQL := fmt.Sprintf("GRANT %s ON %s TO %s", priv, object, privilege.Principal)
-			if strings.Contains(strings.ToUpper(priv), "WITH GRANT OPTION") {
-				grantSQL = fmt.Sprintf("GRANT %s ON %s TO %s WITH GRANT OPTION", strings.Replace(priv, " WITH GRANT OPTION", "", 1), object, privilege.Principal)
-			}
-			_, err := c.DB.Exec(grantSQL)
-			if err != nil {
-				return err
-			}
+	for _, priv := range privilege.Privileges {
+		grantSQL := fmt.Sprintf("GRANT %s ON %s TO %s%s%s", priv.Name, object, privilege.Principal, priv.options(), containerClause(privilege.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+
+	// Grant the desired column privileges. This also restores columns of a
+	// privilege that was revoked above.
+	for priv, columns := range privilege.ColumnPrivileges {
+		if len(columns) == 0 {
+			continue
+		}
+		grantSQL := fmt.Sprintf("GRANT %s (%s) ON %s TO %s%s", priv, strings.Join(columns, ","), object, privilege.Principal, containerClause(privilege.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCurrentColumnPrivileges returns the current column-level privileges on an object for a user.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//	owner: The owner of the object, or an empty string to match any owner.
+//	object: The name of the object to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
+//
+// Returns:
+//
+//	A map from privilege to the list of columns it is granted on, and an error if the check fails.
+func (c *Client) GetCurrentColumnPrivileges(principal, owner, object, containerScope string) (map[string][]string, error) {
+	sql := "SELECT privilege, column_name FROM dba_col_privs WHERE grantee = UPPER(:1) AND table_name = UPPER(:2)"
+	args := []any{principal, object}
+	if owner != "" {
+		sql += " AND owner = UPPER(:3)"
+		args = append(args, owner)
+	}
+	sql += containerFilter(containerScope) + " ORDER BY privilege, column_name"
+
+	rows, err := c.DB.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := map[string][]string{}
+	for rows.Next() {
+		var privilege, column string
+		if err := rows.Scan(&privilege, &column); err != nil {
+			return nil, err
+		}
+		privileges[privilege] = append(privileges[privilege], column)
+	}
+	return privileges, rows.Err()
+}
+
+// objectClause returns the target of a GRANT or REVOKE ... ON statement for an object.
+// Editions, users, Java sources and resources and mining models need their
+// type in the statement, e.g. GRANT USE ON EDITION or GRANT INHERIT PRIVILEGES ON USER.
+func objectClause(privilege ObjectPrivilege) string {
+	object := privilege.Object
+	if privilege.Owner != "" {
+		object = fmt.Sprintf("%s.%s", privilege.Owner, privilege.Object)
+	}
+	switch strings.ToUpper(privilege.ObjectType) {
+	case "EDITION", "USER":
+		return fmt.Sprintf("%s %s", strings.ToUpper(privilege.ObjectType), privilege.Object)
+	case "JAVA SOURCE", "JAVA RESOURCE", "MINING MODEL":
+		return fmt.Sprintf("%s %s", strings.ToUpper(privilege.ObjectType), object)
+	}
+	return object
+}
+
+// ObjectExists checks if an object of a given type exists. Users are looked up
+// in dba_users, all other types in dba_objects.
+//
+// Parameters:
+//
+//	owner: The owner of the object, or an empty string to match any owner.
+//	object: The name of the object to check.
+//	objectType: The type of the object, e.g. "TABLE" or "USER".
+//
+// Returns:
+//
+//	True if the object exists, and an error if the check fails.
+func (c *Client) ObjectExists(owner, object, objectType string) (bool, error) {
+	if strings.EqualFold(objectType, "USER") {
+		return c.UserExists(object)
+	}
+
+	var count int
+	sql := "SELECT COUNT(*) FROM dba_objects WHERE object_name = UPPER(:1) AND object_type = UPPER(:2)"
+	args := []any{object, objectType}
+	if owner != "" && !strings.EqualFold(objectType, "EDITION") {
+		sql += " AND owner = UPPER(:3)"
+		args = append(args, owner)
+	}
+	if err := c.DB.QueryRow(sql, args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ColumnsFor returns the columns of a privilege in a column privilege map,
+// ignoring the case of the privilege.
+//
+// Parameters:
+//
+//	columnPrivileges: A map from privilege to the list of columns it is granted on.
+//	privilege: The privilege whose columns should be returned.
+//
+// Returns:
+//
+//	The columns of the privilege, or nil if it is not in the map.
+func ColumnsFor(columnPrivileges map[string][]string, privilege string) []string {
+	for priv, columns := range columnPrivileges {
+		if strings.EqualFold(priv, privilege) {
+			return columns
 		}
 	}
 	return nil
@@ -141,40 +282,31 @@ func (c *Client) GrantObjectPrivileges(privilege ObjectPrivilege) error {
 //	An error if the grant operation fails.
 func (c *Client) GrantDirectoryPrivileges(privilege DirectoryPrivilege) error {
 	if privilege.GrantsMode == "enforce" {
-		currentPrivs, err := c.GetCurrentDirectoryPrivileges(privilege.Principal, privilege.Directory)
+		if err := c.checkEnforcePrincipal(privilege.Principal, privilege.AllowOracleMaintained); err != nil {
+			return err
+		}
+
+		currentPrivs, err := c.GetCurrentDirectoryPrivileges(privilege.Principal, privilege.Directory, privilege.ContainerScope)
 		if err != nil {
 			return err
 		}
 
-		// Revoke privileges that are not in the desired list
-		for _, currentPriv := range currentPrivs {
-			found := false
-			for _, desiredPriv := range privilege.Privileges {
-				if strings.EqualFold(currentPriv, desiredPriv) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				revokeSQL := fmt.Sprintf("REVOKE %s ON DIRECTORY %s FROM %s", currentPriv, privilege.Directory, privilege.Principal)
-				if _, err := c.DB.Exec(revokeSQL); err != nil {
-					return err
-				}
+		// Revoke privileges that are not in the desired list, or that hold a
+		// grant option that is no longer desired
+		_, toRevoke := diffPrivileges(currentPrivs, privilege.Privileges, true, nil)
+		for _, priv := range revocable(privilege.Principal, toRevoke, privilege.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON DIRECTORY %s FROM %s%s", priv.Name, privilege.Directory, privilege.Principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
 			}
 		}
 	}
 
 	// Grant the desired privileges
-	if len(privilege.Privileges) > 0 {
-		for _, priv := range privilege.Privileges {
-			grantSQL := fmt.Sprintf("GRANT %s ON DIRECTORY %s TO %s", priv, privilege.Directory, privilege.Principal)
-			if strings.Contains(strings.ToUpper(priv), "WITH GRANT OPTION") {
-				grantSQL = fmt.Sprintf("GRANT %s ON DIRECTORY %s TO %s WITH GRANT OPTION", strings.Replace(priv, " WITH GRANT OPTION", "", 1), privilege.Directory, privilege.Principal)
-			}
-			_, err := c.DB.Exec(grantSQL)
-			if err != nil {
-				return err
-			}
+	for _, priv := range privilege.Privileges {
+		grantSQL := fmt.Sprintf("GRANT %s ON DIRECTORY %s TO %s%s%s", priv.Name, privilege.Directory, privilege.Principal, priv.options(), containerClause(privilege.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -185,13 +317,14 @@ func (c *Client) GrantDirectoryPrivileges(privilege DirectoryPrivilege) error {
 // Parameters:
 //
 //	principal: The name of the user or role to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
 //
 // Returns:
 //
-//	A slice of strings containing the current system privileges, and an error if the check fails.
-func (c *Client) GetCurrentSystemPrivileges(principal string) ([]string, error) {
-	var privileges []string
-	sql := "SELECT privilege, admin_option FROM dba_sys_privs WHERE grantee = UPPER(:1)"
+//	A slice of Privilege structs containing the current system privileges, and an error if the check fails.
+func (c *Client) GetCurrentSystemPrivileges(principal, containerScope string) ([]Privilege, error) {
+	var privileges []Privilege
+	sql := "SELECT privilege, admin_option FROM dba_sys_privs WHERE grantee = UPPER(:1)" + containerFilter(containerScope)
 	rows, err := c.DB.Query(sql, principal)
 	if err != nil {
 		return nil, err
@@ -204,7 +337,7 @@ func (c *Client) GetCurrentSystemPrivileges(principal string) ([]string, error)
 		if err := rows.Scan(&privilege, &adminOption); err != nil {
 			return nil, err
 		}
-		privileges = append(privileges, grantOption(privilege, adminOption))
+		privileges = append(privileges, Privilege{Name: privilege, AdminOption: adminOption == "YES"})
 	}
 	return privileges, nil
 }
@@ -214,21 +347,29 @@ func (c *Client) GetCurrentSystemPrivileges(principal string) ([]string, error)
 // Parameters:
 //
 //	principal: The name of the user or role to check.
+//	owner: The owner of the object, or an empty string to match any owner.
 //	object: The name of the object to check.
+//	objectType: The type of the object as reported by dba_tab_privs.type, or an empty string to match any type.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
 //
 // Returns:
 //
-//	A slice of strings containing the current object privileges, and an error if the check fails.
-func (c *Client) GetCurrentObjectPrivileges(principal, owner, object string) ([]string, error) {
-	var privileges []string
-	sql := "SELECT privilege, grantable FROM dba_tab_privs WHERE grantee = UPPER(:1) AND owner = UPPER(:2) AND table_name = UPPER(:3)"
-	if owner == "" {
-		sql = "SELECT privilege, grantable FROM dba_tab_privs WHERE grantee = UPPER(:1) AND table_name = UPPER(:2)"
+//	A slice of Privilege structs containing the current object privileges, and an error if the check fails.
+func (c *Client) GetCurrentObjectPrivileges(principal, owner, object, objectType, containerScope string) ([]Privilege, error) {
+	var privileges []Privilege
+	sql := "SELECT privilege, grantable, hierarchy FROM dba_tab_privs WHERE grantee = UPPER(:1) AND table_name = UPPER(:2)"
+	args := []any{principal, object}
+	if owner != "" {
+		args = append(args, owner)
+		sql += fmt.Sprintf(" AND owner = UPPER(:%d)", len(args))
 	}
-	rows, err := c.DB.Query(sql, principal, owner, object)
-	if owner == "" {
-		rows, err = c.DB.Query(sql, principal, object)
+	if objectType != "" {
+		args = append(args, objectType)
+		sql += fmt.Sprintf(" AND type = UPPER(:%d)", len(args))
 	}
+	sql += containerFilter(containerScope)
+
+	rows, err := c.DB.Query(sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -237,10 +378,11 @@ func (c *Client) GetCurrentObjectPrivileges(principal, owner, object string) ([]
 	for rows.Next() {
 		var privilege string
 		var grantable string
-		if err := rows.Scan(&privilege, &grantable); err != nil {
+		var hierarchy string
+		if err := rows.Scan(&privilege, &grantable, &hierarchy); err != nil {
 			return nil, err
 		}
-		privileges = append(privileges, grantOption(privilege, grantable))
+		privileges = append(privileges, Privilege{Name: privilege, GrantOption: grantable == "YES", HierarchyOption: hierarchy == "YES"})
 	}
 	return privileges, nil
 }
@@ -251,13 +393,14 @@ func (c *Client) GetCurrentObjectPrivileges(principal, owner, object string) ([]
 //
 //	principal: The name of the user or role to check.
 //	directory: The name of the directory to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
 //
 // Returns:
 //
-//	A slice of strings containing the current directory privileges and an error if the check fails.
-func (c *Client) GetCurrentDirectoryPrivileges(principal, directory string) ([]string, error) {
-	var privileges []string
-	sql := "SELECT privilege, grantable FROM all_tab_privs WHERE grantee = UPPER(:1) AND table_name = UPPER(:2) AND type = 'DIRECTORY'"
+//	A slice of Privilege structs containing the current directory privileges and an error if the check fails.
+func (c *Client) GetCurrentDirectoryPrivileges(principal, directory, containerScope string) ([]Privilege, error) {
+	var privileges []Privilege
+	sql := "SELECT privilege, grantable FROM all_tab_privs WHERE grantee = UPPER(:1) AND table_name = UPPER(:2) AND type = 'DIRECTORY'" + containerFilter(containerScope)
 	rows, err := c.DB.Query(sql, principal, directory)
 	if err != nil {
 		return nil, err
@@ -270,14 +413,7 @@ func (c *Client) GetCurrentDirectoryPrivileges(principal, directory string) ([]s
 		if err := rows.Scan(&privilege, &grantable); err != nil {
 			return nil, err
 		}
-		privileges = append(privileges, grantOption(privilege, grantable))
+		privileges = append(privileges, Privilege{Name: privilege, GrantOption: grantable == "YES"})
 	}
 	return privileges, nil
 }
-
-func grantOption(privilege, option string) string {
-	if option == "YES" {
-		return fmt.Sprintf("%s WITH ADMIN OPTION", privilege)
-	}
-	return privilege
-}
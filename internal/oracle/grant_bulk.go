@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BulkObjectPrivilege represents object privileges granted on every object of a schema matching a type and name pattern.
+type BulkObjectPrivilege struct {
+	Principal      string      // The user or role to whom the privileges should be granted.
+	Owner          string      // The owner of the objects.
+	Objects        []string    // The names of the objects on which to grant or revoke the privileges.
+	Privileges     []Privilege // A list of object privileges to grant.
+	GrantsMode     string      // The grants mode, either "enforce" or "append".
+	ContainerScope string      // The container scope, either "current" or "all" for common grants in a CDB.
+	Managed        []string    // For PUBLIC, the privileges that enforce mode may revoke, i.e. those granted by the provider.
+}
+
+// MatchingObjects returns the objects of a schema that match a list of object types and a name pattern.
+// Patterns are case-insensitive and "*" matches any sequence of characters.
+// Objects in the recycle bin are never returned.
+//
+// Parameters:
+//
+//	owner: The owner of the objects.
+//	objectTypes: The object types to match, e.g. "TABLE" or "VIEW".
+//	include: The pattern object names must match.
+//	exclude: The pattern object names must not match, or an empty string to exclude nothing.
+//
+// Returns:
+//
+//	A sorted slice of object names, and an error if the query fails.
+func (c *Client) MatchingObjects(owner string, objectTypes []string, include, exclude string) ([]string, error) {
+	if len(objectTypes) == 0 {
+		return []string{}, nil
+	}
+
+	binds := make([]string, len(objectTypes))
+	args := []any{owner}
+	for i, objectType := range objectTypes {
+		binds[i] = fmt.Sprintf("UPPER(:%d)", i+2)
+		args = append(args, objectType)
+	}
+	sql := fmt.Sprintf("SELECT DISTINCT object_name FROM dba_objects WHERE owner = UPPER(:1) AND object_type IN (%s) AND object_name NOT LIKE 'BIN$%%'", strings.Join(binds, ","))
+
+	names, err := c.queryStrings(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []string{}
+	for _, name := range names {
+		if !matchesPattern(include, name) {
+			continue
+		}
+		if exclude != "" && matchesPattern(exclude, name) {
+			continue
+		}
+		objects = append(objects, name)
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// GetCurrentOwnerObjectPrivileges returns the current privileges of a user or role on all objects of a schema.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//	owner: The owner of the objects.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
+//
+// Returns:
+//
+//	A map from object name to its privileges, and an error if the check fails.
+func (c *Client) GetCurrentOwnerObjectPrivileges(principal, owner, containerScope string) (map[string][]Privilege, error) {
+	sql := "SELECT table_name, privilege, grantable, hierarchy FROM dba_tab_privs WHERE grantee = UPPER(:1) AND owner = UPPER(:2)" + containerFilter(containerScope)
+	rows, err := c.DB.Query(sql, principal, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	privileges := map[string][]Privilege{}
+	for rows.Next() {
+		var object, privilege, grantable, hierarchy string
+		if err := rows.Scan(&object, &privilege, &grantable, &hierarchy); err != nil {
+			return nil, err
+		}
+		privileges[object] = append(privileges[object], Privilege{Name: privilege, GrantOption: grantable == "YES", HierarchyOption: hierarchy == "YES"})
+	}
+	return privileges, rows.Err()
+}
+
+// GetGrantedObjects returns the objects of a schema on which a user or role holds all of the given privileges.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//	owner: The owner of the objects.
+//	privileges: The privileges that must be granted, including their grant and hierarchy options.
+//	containerScope: The container scope of the grants to check, either "current" or "all".
+//
+// Returns:
+//
+//	A sorted slice of object names, and an error if the check fails.
+func (c *Client) GetGrantedObjects(principal, owner string, privileges []Privilege, containerScope string) ([]string, error) {
+	current, err := c.GetCurrentOwnerObjectPrivileges(principal, owner, containerScope)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []string{}
+	for object, granted := range current {
+		complete := true
+		for _, privilege := range privileges {
+			grant, found := FindPrivilege(granted, privilege.Name)
+			if !found || (privilege.GrantOption && !grant.GrantOption) || (privilege.HierarchyOption && !grant.HierarchyOption) {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			objects = append(objects, object)
+		}
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// GrantBulkObjectPrivileges grants object privileges on each of a list of objects.
+// In enforce mode, other privileges of the principal on these objects are revoked.
+//
+// Parameters:
+//
+//	privilege: A BulkObjectPrivilege struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	An error if a grant operation fails.
+func (c *Client) GrantBulkObjectPrivileges(privilege BulkObjectPrivilege) error {
+	for _, object := range privilege.Objects {
+		err := c.GrantObjectPrivileges(ObjectPrivilege{
+			Principal:      privilege.Principal,
+			Owner:          privilege.Owner,
+			Object:         object,
+			Privileges:     privilege.Privileges,
+			GrantsMode:     privilege.GrantsMode,
+			ContainerScope: privilege.ContainerScope,
+			Managed:        privilege.Managed,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to grant privileges on %s.%s: %w", privilege.Owner, object, err)
+		}
+	}
+	return nil
+}
+
+// RevokeBulkObjectPrivileges revokes object privileges from each of a list of objects.
+// Only privileges that are currently granted are revoked, so objects that were dropped are skipped.
+// For PUBLIC, only the managed privileges are revoked.
+//
+// Parameters:
+//
+//	privilege: A BulkObjectPrivilege struct containing the details of the privileges to be revoked.
+//
+// Returns:
+//
+//	An error if a revoke operation fails.
+func (c *Client) RevokeBulkObjectPrivileges(privilege BulkObjectPrivilege) error {
+	current, err := c.GetCurrentOwnerObjectPrivileges(privilege.Principal, privilege.Owner, privilege.ContainerScope)
+	if err != nil {
+		return err
+	}
+
+	privileges := revocable(privilege.Principal, privilege.Privileges, privilege.Managed)
+	for _, object := range privilege.Objects {
+		granted := current[object]
+		for _, priv := range privileges {
+			if _, found := FindPrivilege(granted, priv.Name); !found {
+				continue
+			}
+			revokeSQL := fmt.Sprintf("REVOKE %s ON %s.%s FROM %s%s", priv.Name, privilege.Owner, object, privilege.Principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return fmt.Errorf("unable to revoke privileges on %s.%s: %w", privilege.Owner, object, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestGrantBulkObjectPrivileges(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	for _, username := range []string{"testbulkowner", "testbulkuser"} {
+		exists, err := client.UserExists(username)
+		assert.NoError(t, err)
+		if exists {
+			assert.NoError(t, client.DropUser(username, true))
+		}
+		assert.NoError(t, client.CreateUser(oracle.User{Username: username, Password: "testpassword", AuthenticationType: "password"}))
+	}
+	defer func() {
+		assert.NoError(t, client.DropUser("testbulkuser", true))
+		assert.NoError(t, client.DropUser("testbulkowner", true))
+	}()
+
+	for _, table := range []string{"app_orders", "app_customers", "app_orders_staging", "audit_log"} {
+		_, err = client.ExecuteSQL("CREATE TABLE testbulkowner." + table + " (id NUMBER)")
+		assert.NoError(t, err)
+	}
+
+	objects, err := client.MatchingObjects("testbulkowner", []string{"TABLE"}, "app_*", "*_staging")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"APP_CUSTOMERS", "APP_ORDERS"}, objects)
+
+	grant := oracle.BulkObjectPrivilege{
+		Principal:  "testbulkuser",
+		Owner:      "testbulkowner",
+		Objects:    objects,
+		Privileges: []oracle.Privilege{{Name: "SELECT"}},
+		GrantsMode: "append",
+	}
+	assert.NoError(t, client.GrantBulkObjectPrivileges(grant))
+
+	granted, err := client.GetGrantedObjects("testbulkuser", "testbulkowner", []oracle.Privilege{{Name: "SELECT"}}, "current")
+	assert.NoError(t, err)
+	assert.Equal(t, objects, granted)
+
+	assert.NoError(t, client.RevokeBulkObjectPrivileges(grant))
+
+	granted, err = client.GetGrantedObjects("testbulkuser", "testbulkowner", []oracle.Privilege{{Name: "SELECT"}}, "current")
+	assert.NoError(t, err)
+	assert.Empty(t, granted)
+}
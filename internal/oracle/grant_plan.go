@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GrantPlan describes the privileges or roles that applying a grant would
+// grant and revoke, so that destructive changes can be previewed in a plan.
+type GrantPlan struct {
+	ToGrant  []string // The grants that would be added, e.g. "SELECT WITH GRANT OPTION".
+	ToRevoke []string // The grants that would be revoked.
+}
+
+// PlanSystemPrivileges returns the system privileges that GrantSystemPrivileges would grant and revoke.
+//
+// Parameters:
+//
+//	grant: A Grant struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	A GrantPlan with the privileges to grant and revoke, and an error if the current privileges cannot be read.
+func (c *Client) PlanSystemPrivileges(grant Grant) (GrantPlan, error) {
+	current, err := c.GetCurrentSystemPrivileges(grant.Principal, grant.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+	toGrant, toRevoke := diffPrivileges(current, grant.Privileges, grant.GrantsMode == "enforce", grant.Ignore)
+	return newGrantPlan(toGrant, revocable(grant.Principal, toRevoke, grant.Managed)), nil
+}
+
+// PlanObjectPrivileges returns the object and column privileges that GrantObjectPrivileges would grant and revoke.
+// Column privileges are described as e.g. "UPDATE (EMAIL, PHONE)".
+//
+// Parameters:
+//
+//	privilege: An ObjectPrivilege struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	A GrantPlan with the privileges to grant and revoke, and an error if the current privileges cannot be read.
+func (c *Client) PlanObjectPrivileges(privilege ObjectPrivilege) (GrantPlan, error) {
+	current, err := c.GetCurrentObjectPrivileges(privilege.Principal, privilege.Owner, privilege.Object, privilege.ObjectType, privilege.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+	currentColumns, err := c.GetCurrentColumnPrivileges(privilege.Principal, privilege.Owner, privilege.Object, privilege.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+
+	enforce := privilege.GrantsMode == "enforce"
+	toGrant, toRevoke := diffPrivileges(current, privilege.Privileges, enforce, nil)
+	plan := newGrantPlan(toGrant, revocable(privilege.Principal, toRevoke, privilege.Managed))
+
+	columnsToGrant, columnsToRevoke := diffColumnPrivileges(currentColumns, privilege.ColumnPrivileges, enforce)
+	columnsToRevoke = revocableColumns(privilege.Principal, columnsToRevoke, privilege.Managed)
+	for priv, columns := range columnsToGrant {
+		plan.ToGrant = append(plan.ToGrant, fmt.Sprintf("%s (%s)", strings.ToUpper(priv), strings.Join(columns, ", ")))
+	}
+	for priv, columns := range columnsToRevoke {
+		plan.ToRevoke = append(plan.ToRevoke, fmt.Sprintf("%s (%s)", strings.ToUpper(priv), strings.Join(columns, ", ")))
+	}
+	sort.Strings(plan.ToGrant)
+	sort.Strings(plan.ToRevoke)
+	return plan, nil
+}
+
+// PlanDirectoryPrivileges returns the directory privileges that GrantDirectoryPrivileges would grant and revoke.
+//
+// Parameters:
+//
+//	privilege: A DirectoryPrivilege struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	A GrantPlan with the privileges to grant and revoke, and an error if the current privileges cannot be read.
+func (c *Client) PlanDirectoryPrivileges(privilege DirectoryPrivilege) (GrantPlan, error) {
+	current, err := c.GetCurrentDirectoryPrivileges(privilege.Principal, privilege.Directory, privilege.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+	toGrant, toRevoke := diffPrivileges(current, privilege.Privileges, privilege.GrantsMode == "enforce", nil)
+	return newGrantPlan(toGrant, revocable(privilege.Principal, toRevoke, privilege.Managed)), nil
+}
+
+// PlanSchemaPrivileges returns the schema privileges that GrantSchemaPrivileges would grant and revoke.
+//
+// Parameters:
+//
+//	privilege: A SchemaPrivilege struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	A GrantPlan with the privileges to grant and revoke, and an error if the current privileges cannot be read.
+func (c *Client) PlanSchemaPrivileges(privilege SchemaPrivilege) (GrantPlan, error) {
+	current, err := c.GetCurrentSchemaPrivileges(privilege.Principal, privilege.Schema, privilege.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+	toGrant, toRevoke := diffPrivileges(current, privilege.Privileges, privilege.GrantsMode == "enforce", nil)
+	return newGrantPlan(toGrant, revocable(privilege.Principal, toRevoke, privilege.Managed)), nil
+}
+
+// PlanRoles returns the roles that GrantRoles would grant and revoke.
+// A role whose admin option is removed is revoked and granted again, even in append mode.
+//
+// Parameters:
+//
+//	grant: A GrantRole struct containing the details of the roles to be granted.
+//
+// Returns:
+//
+//	A GrantPlan with the roles to grant and revoke, and an error if the current roles cannot be read.
+func (c *Client) PlanRoles(grant GrantRole) (GrantPlan, error) {
+	currentGrants, err := c.GetCurrentRoleGrants(grant.Principal, grant.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+
+	current := make([]Privilege, len(currentGrants))
+	for i, currentGrant := range currentGrants {
+		current[i] = Privilege{Name: currentGrant.Role, AdminOption: currentGrant.AdminOption}
+	}
+	desired := make([]Privilege, len(grant.Roles))
+	for i, role := range grant.Roles {
+		desired[i] = Privilege{Name: role, AdminOption: ContainsFold(grant.AdminRoles, role)}
+	}
+
+	toGrant, toRevoke := diffPrivileges(current, desired, grant.GrantsMode == "enforce", grant.Ignore)
+	toRevoke = revocable(grant.Principal, toRevoke, grant.Managed)
+
+	// GrantRoles removes an admin option in append mode and from ignored roles as well
+	for _, currentRole := range current {
+		desiredRole, found := FindPrivilege(desired, currentRole.Name)
+		if !found || !hasUndesiredOption(currentRole, desiredRole) {
+			continue
+		}
+		if _, revoked := FindPrivilege(toRevoke, currentRole.Name); !revoked {
+			toRevoke = append(toRevoke, currentRole)
+		}
+		if _, granted := FindPrivilege(toGrant, desiredRole.Name); !granted {
+			toGrant = append(toGrant, desiredRole)
+		}
+	}
+	return newGrantPlan(toGrant, toRevoke), nil
+}
+
+// PlanBulkObjectPrivileges returns the object privileges that granting
+// privileges on a list of objects and then revoking privileges from other
+// objects would grant and revoke. Each entry names its object, e.g.
+// "SELECT ON APP.ORDERS".
+//
+// Parameters:
+//
+//	grant: A BulkObjectPrivilege struct containing the details of the privileges to be granted.
+//	revokes: BulkObjectPrivilege structs containing the details of the privileges to be revoked afterwards.
+//
+// Returns:
+//
+//	A GrantPlan with the privileges to grant and revoke, and an error if the current privileges cannot be read.
+func (c *Client) PlanBulkObjectPrivileges(grant BulkObjectPrivilege, revokes []BulkObjectPrivilege) (GrantPlan, error) {
+	current, err := c.GetCurrentOwnerObjectPrivileges(grant.Principal, grant.Owner, grant.ContainerScope)
+	if err != nil {
+		return GrantPlan{}, err
+	}
+
+	plan := GrantPlan{ToGrant: []string{}, ToRevoke: []string{}}
+	onObject := func(privilege Privilege, object string) string {
+		return fmt.Sprintf("%s ON %s.%s", privilege, strings.ToUpper(grant.Owner), object)
+	}
+	for _, object := range grant.Objects {
+		toGrant, toRevoke := diffPrivileges(current[object], grant.Privileges, grant.GrantsMode == "enforce", nil)
+		for _, privilege := range toGrant {
+			plan.ToGrant = append(plan.ToGrant, onObject(privilege, object))
+		}
+		for _, privilege := range revocable(grant.Principal, toRevoke, grant.Managed) {
+			plan.ToRevoke = append(plan.ToRevoke, onObject(privilege, object))
+		}
+	}
+	for _, revoke := range revokes {
+		for _, object := range revoke.Objects {
+			for _, privilege := range revocable(grant.Principal, revoke.Privileges, revoke.Managed) {
+				if granted, found := FindPrivilege(current[object], privilege.Name); found {
+					entry := onObject(granted, object)
+					if !ContainsFold(plan.ToRevoke, entry) {
+						plan.ToRevoke = append(plan.ToRevoke, entry)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(plan.ToGrant)
+	sort.Strings(plan.ToRevoke)
+	return plan, nil
+}
+
+// diffPrivileges compares the current and desired privileges of a grant. In
+// enforce mode, privileges that are not desired and not ignored are revoked,
+// and privileges holding an option that is no longer desired are revoked and
+// granted again. Desired privileges that are missing or lack an option are
+// granted.
+func diffPrivileges(current, desired []Privilege, enforce bool, ignore []string) (toGrant, toRevoke []Privilege) {
+	for _, desiredPriv := range desired {
+		currentPriv, found := FindPrivilege(current, desiredPriv.Name)
+		missingOption := (desiredPriv.AdminOption && !currentPriv.AdminOption) ||
+			(desiredPriv.GrantOption && !currentPriv.GrantOption) ||
+			(desiredPriv.HierarchyOption && !currentPriv.HierarchyOption)
+		if !found || missingOption || (enforce && hasUndesiredOption(currentPriv, desiredPriv)) {
+			toGrant = append(toGrant, desiredPriv)
+		}
+	}
+
+	if !enforce {
+		return toGrant, nil
+	}
+	for _, currentPriv := range current {
+		if IsIgnored(ignore, currentPriv.Name) {
+			continue
+		}
+		desiredPriv, found := FindPrivilege(desired, currentPriv.Name)
+		if !found || hasUndesiredOption(currentPriv, desiredPriv) {
+			toRevoke = append(toRevoke, currentPriv)
+		}
+	}
+	return toGrant, toRevoke
+}
+
+// diffColumnPrivileges compares the current and desired column privileges of
+// an object grant. Oracle can only revoke a column privilege from all of its
+// columns, so in enforce mode a privilege that is removed from some columns is
+// revoked and granted again on the remaining ones.
+func diffColumnPrivileges(current, desired map[string][]string, enforce bool) (toGrant, toRevoke map[string][]string) {
+	toGrant, toRevoke = map[string][]string{}, map[string][]string{}
+	if enforce {
+		for priv, currentColumns := range current {
+			desiredColumns := ColumnsFor(desired, priv)
+			for _, column := range currentColumns {
+				if !ContainsFold(desiredColumns, column) {
+					toRevoke[priv] = currentColumns
+					break
+				}
+			}
+		}
+	}
+
+	for priv, desiredColumns := range desired {
+		if len(desiredColumns) == 0 {
+			continue
+		}
+		if _, revoked := toRevoke[strings.ToUpper(priv)]; revoked {
+			toGrant[priv] = desiredColumns
+			continue
+		}
+		currentColumns := ColumnsFor(current, priv)
+		for _, column := range desiredColumns {
+			if !ContainsFold(currentColumns, column) {
+				toGrant[priv] = append(toGrant[priv], column)
+			}
+		}
+	}
+	return toGrant, toRevoke
+}
+
+// newGrantPlan returns a GrantPlan describing lists of privileges to grant and revoke.
+func newGrantPlan(toGrant, toRevoke []Privilege) GrantPlan {
+	plan := GrantPlan{ToGrant: []string{}, ToRevoke: []string{}}
+	for _, privilege := range toGrant {
+		plan.ToGrant = append(plan.ToGrant, privilege.String())
+	}
+	for _, privilege := range toRevoke {
+		plan.ToRevoke = append(plan.ToRevoke, privilege.String())
+	}
+	sort.Strings(plan.ToGrant)
+	sort.Strings(plan.ToRevoke)
+	return plan
+}
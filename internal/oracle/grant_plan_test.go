@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestPlanGrants(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	testUser := oracle.User{
+		Username:           "testplanuser",
+		Password:           "testpassword",
+		AuthenticationType: "password",
+	}
+
+	exists, err := client.UserExists(testUser.Username)
+	assert.NoError(t, err)
+	if exists {
+		assert.NoError(t, client.DropUser(testUser.Username, true))
+	}
+	assert.NoError(t, client.CreateUser(testUser))
+	defer func() {
+		assert.NoError(t, client.DropUser(testUser.Username, true))
+	}()
+
+	_, err = client.ExecuteSQL("GRANT CREATE SESSION, CREATE TABLE TO " + testUser.Username)
+	assert.NoError(t, err)
+	_, err = client.ExecuteSQL("GRANT CREATE VIEW TO " + testUser.Username + " WITH ADMIN OPTION")
+	assert.NoError(t, err)
+
+	grant := oracle.Grant{
+		Principal:  testUser.Username,
+		Privileges: []oracle.Privilege{{Name: "CREATE SESSION"}, {Name: "CREATE VIEW"}, {Name: "CREATE SEQUENCE", AdminOption: true}},
+		GrantsMode: "append",
+	}
+
+	// Append mode never revokes
+	plan, err := client.PlanSystemPrivileges(grant)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CREATE SEQUENCE WITH ADMIN OPTION"}, plan.ToGrant)
+	assert.Empty(t, plan.ToRevoke)
+
+	// Enforce mode revokes unlisted privileges and undesired admin options, except ignored ones
+	grant.GrantsMode = "enforce"
+	grant.Ignore = []string{"CREATE TAB*"}
+	plan, err = client.PlanSystemPrivileges(grant)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CREATE SEQUENCE WITH ADMIN OPTION", "CREATE VIEW"}, plan.ToGrant)
+	assert.Equal(t, []string{"CREATE VIEW WITH ADMIN OPTION"}, plan.ToRevoke)
+
+	grant.Ignore = nil
+	plan, err = client.PlanSystemPrivileges(grant)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CREATE TABLE", "CREATE VIEW WITH ADMIN OPTION"}, plan.ToRevoke)
+
+	// Once applied, nothing is left to change
+	assert.NoError(t, client.GrantSystemPrivileges(grant))
+	plan, err = client.PlanSystemPrivileges(grant)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.ToGrant)
+	assert.Empty(t, plan.ToRevoke)
+
+	// Roles lose their admin option in append mode as well
+	_, err = client.ExecuteSQL("GRANT CONNECT TO " + testUser.Username + " WITH ADMIN OPTION")
+	assert.NoError(t, err)
+	rolePlan, err := client.PlanRoles(oracle.GrantRole{
+		Principal:  testUser.Username,
+		Roles:      []string{"connect", "resource"},
+		GrantsMode: "append",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"connect", "resource"}, rolePlan.ToGrant)
+	assert.Equal(t, []string{"connect WITH ADMIN OPTION"}, rolePlan.ToRevoke)
+
+	// Enforce mode only revokes the privileges granted by the provider from PUBLIC
+	publicGrant := oracle.ObjectPrivilege{
+		Principal:  "public",
+		Owner:      "sys",
+		Object:     "dbms_output",
+		Privileges: []oracle.Privilege{},
+		GrantsMode: "enforce",
+	}
+	plan, err = client.PlanObjectPrivileges(publicGrant)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.ToRevoke)
+
+	publicGrant.Managed = []string{"execute"}
+	plan, err = client.PlanObjectPrivileges(publicGrant)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EXECUTE"}, plan.ToRevoke)
+}
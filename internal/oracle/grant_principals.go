@@ -0,0 +1,410 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// principalGroup is a list of principals that are missing the same grants,
+// so that they can be granted in a single GRANT ... TO a, b, c statement.
+type principalGroup struct {
+	principals []string
+	privileges []Privilege
+	columns    map[string][]string
+}
+
+// GrantSystemPrivilegesToPrincipals grants system privileges to several users or roles.
+//
+// The privileges of each principal are compared with the desired privileges
+// on their own, and principals that are missing the same privileges are
+// granted them in a single statement. grant.Principal is ignored.
+//
+// Parameters:
+//
+//	grant: A Grant struct containing the details of the privileges to be granted.
+//	principals: The users or roles to whom the privileges should be granted.
+//
+// Returns:
+//
+//	An error if a grant or revoke operation fails.
+func (c *Client) GrantSystemPrivilegesToPrincipals(grant Grant, principals []string) error {
+	enforce := grant.GrantsMode == "enforce"
+	toGrant := map[string][]Privilege{}
+	for _, principal := range principals {
+		if enforce {
+			if err := c.checkEnforcePrincipal(principal, grant.AllowOracleMaintained); err != nil {
+				return err
+			}
+		}
+
+		current, err := c.GetCurrentSystemPrivileges(principal, grant.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		var toRevoke []Privilege
+		toGrant[principal], toRevoke = diffPrivileges(current, grant.Privileges, enforce, grant.Ignore)
+		for _, priv := range revocable(principal, toRevoke, grant.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s FROM %s%s", priv.Name, principal, containerClause(grant.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range groupPrincipals(principals, toGrant, nil) {
+		if err := c.execGrants(group.privileges, "", group.principals, grant.ContainerScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeSystemPrivilegesFromPrincipals revokes system privileges from several users or roles.
+//
+// Only the privileges of grant that a principal holds are revoked, so its
+// other system privileges are left alone. grant.Principal is ignored.
+//
+// Parameters:
+//
+//	grant: A Grant struct containing the details of the privileges to be revoked.
+//	principals: The users or roles from whom the privileges should be revoked.
+//
+// Returns:
+//
+//	An error if a revoke operation fails.
+func (c *Client) RevokeSystemPrivilegesFromPrincipals(grant Grant, principals []string) error {
+	for _, principal := range principals {
+		current, err := c.GetCurrentSystemPrivileges(principal, grant.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		for _, priv := range grant.Privileges {
+			if _, found := FindPrivilege(current, priv.Name); !found {
+				continue
+			}
+			revokeSQL := fmt.Sprintf("REVOKE %s FROM %s%s", priv.Name, principal, containerClause(grant.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GrantObjectPrivilegesToPrincipals grants object privileges to several users or roles.
+//
+// The privileges of each principal are compared with the desired privileges
+// on their own, and principals that are missing the same privileges are
+// granted them in a single statement. privilege.Principal is ignored. As in
+// GrantObjectPrivileges, the desired grants of a revoked privilege are
+// granted again.
+//
+// Parameters:
+//
+//	privilege: An ObjectPrivilege struct containing the details of the privileges to be granted.
+//	principals: The users or roles to whom the privileges should be granted.
+//
+// Returns:
+//
+//	An error if a grant or revoke operation fails.
+func (c *Client) GrantObjectPrivilegesToPrincipals(privilege ObjectPrivilege, principals []string) error {
+	object := objectClause(privilege)
+	enforce := privilege.GrantsMode == "enforce"
+	toGrant := map[string][]Privilege{}
+	columnsToGrant := map[string]map[string][]string{}
+	for _, principal := range principals {
+		if enforce {
+			if err := c.checkEnforcePrincipal(principal, privilege.AllowOracleMaintained); err != nil {
+				return err
+			}
+		}
+
+		current, err := c.GetCurrentObjectPrivileges(principal, privilege.Owner, privilege.Object, privilege.ObjectType, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+		currentColumns, err := c.GetCurrentColumnPrivileges(principal, privilege.Owner, privilege.Object, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		var toRevoke []Privilege
+		var columnsToRevoke map[string][]string
+		toGrant[principal], toRevoke = diffPrivileges(current, privilege.Privileges, enforce, nil)
+		columnsToGrant[principal], columnsToRevoke = diffColumnPrivileges(currentColumns, privilege.ColumnPrivileges, enforce)
+
+		var revokes []string
+		for _, priv := range revocable(principal, toRevoke, privilege.Managed) {
+			revokes = append(revokes, priv.Name)
+		}
+		for priv := range revocableColumns(principal, columnsToRevoke, privilege.Managed) {
+			revokes = append(revokes, priv)
+		}
+		for _, priv := range revokes {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON %s FROM %s%s", priv, object, principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+		toGrant[principal], columnsToGrant[principal] = restoreRevoked(revokes, privilege, toGrant[principal], columnsToGrant[principal])
+	}
+
+	for _, group := range groupPrincipals(principals, toGrant, columnsToGrant) {
+		if err := c.execGrants(group.privileges, " ON "+object, group.principals, privilege.ContainerScope); err != nil {
+			return err
+		}
+
+		columnPrivileges := make([]string, 0, len(group.columns))
+		for priv := range group.columns {
+			columnPrivileges = append(columnPrivileges, priv)
+		}
+		sort.Strings(columnPrivileges)
+		for _, priv := range columnPrivileges {
+			grantSQL := fmt.Sprintf("GRANT %s (%s) ON %s TO %s%s", priv, strings.Join(group.columns[priv], ","), object, strings.Join(group.principals, ", "), containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(grantSQL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RevokeObjectPrivilegesFromPrincipals revokes object privileges from several users or roles.
+//
+// Only the privileges and column privileges of privilege that a principal
+// holds are revoked, so other grants on the object are left alone.
+// privilege.Principal is ignored.
+//
+// Parameters:
+//
+//	privilege: An ObjectPrivilege struct containing the details of the privileges to be revoked.
+//	principals: The users or roles from whom the privileges should be revoked.
+//
+// Returns:
+//
+//	An error if a revoke operation fails.
+func (c *Client) RevokeObjectPrivilegesFromPrincipals(privilege ObjectPrivilege, principals []string) error {
+	object := objectClause(privilege)
+	for _, principal := range principals {
+		current, err := c.GetCurrentObjectPrivileges(principal, privilege.Owner, privilege.Object, privilege.ObjectType, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+		currentColumns, err := c.GetCurrentColumnPrivileges(principal, privilege.Owner, privilege.Object, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		var revokes []string
+		for _, priv := range privilege.Privileges {
+			if _, found := FindPrivilege(current, priv.Name); found && !ContainsFold(revokes, priv.Name) {
+				revokes = append(revokes, priv.Name)
+			}
+		}
+		for priv := range privilege.ColumnPrivileges {
+			if len(ColumnsFor(currentColumns, priv)) > 0 && !ContainsFold(revokes, priv) {
+				revokes = append(revokes, priv)
+			}
+		}
+		for _, priv := range revokes {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON %s FROM %s%s", priv, object, principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restoreRevoked adds the desired privileges and column privileges named in
+// revoked to the privileges to grant. Revoking an object privilege removes it
+// from the object and from all of its columns, so the desired grants of that
+// privilege that were already held have to be granted again.
+func restoreRevoked(revoked []string, privilege ObjectPrivilege, toGrant []Privilege, columnsToGrant map[string][]string) ([]Privilege, map[string][]string) {
+	for _, desired := range privilege.Privileges {
+		if !ContainsFold(revoked, desired.Name) {
+			continue
+		}
+		if _, found := FindPrivilege(toGrant, desired.Name); !found {
+			toGrant = append(toGrant, desired)
+		}
+	}
+	for priv, columns := range privilege.ColumnPrivileges {
+		if len(columns) > 0 && ContainsFold(revoked, priv) {
+			columnsToGrant[priv] = columns
+		}
+	}
+	return toGrant, columnsToGrant
+}
+
+// GrantDirectoryPrivilegesToPrincipals grants directory privileges to several users or roles.
+//
+// The privileges of each principal are compared with the desired privileges
+// on their own, and principals that are missing the same privileges are
+// granted them in a single statement. privilege.Principal is ignored.
+//
+// Parameters:
+//
+//	privilege: A DirectoryPrivilege struct containing the details of the privileges to be granted.
+//	principals: The users or roles to whom the privileges should be granted.
+//
+// Returns:
+//
+//	An error if a grant or revoke operation fails.
+func (c *Client) GrantDirectoryPrivilegesToPrincipals(privilege DirectoryPrivilege, principals []string) error {
+	enforce := privilege.GrantsMode == "enforce"
+	toGrant := map[string][]Privilege{}
+	for _, principal := range principals {
+		if enforce {
+			if err := c.checkEnforcePrincipal(principal, privilege.AllowOracleMaintained); err != nil {
+				return err
+			}
+		}
+
+		current, err := c.GetCurrentDirectoryPrivileges(principal, privilege.Directory, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		var toRevoke []Privilege
+		toGrant[principal], toRevoke = diffPrivileges(current, privilege.Privileges, enforce, nil)
+		for _, priv := range revocable(principal, toRevoke, privilege.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON DIRECTORY %s FROM %s%s", priv.Name, privilege.Directory, principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, group := range groupPrincipals(principals, toGrant, nil) {
+		if err := c.execGrants(group.privileges, " ON DIRECTORY "+privilege.Directory, group.principals, privilege.ContainerScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeDirectoryPrivilegesFromPrincipals revokes directory privileges from several users or roles.
+//
+// Only the privileges of privilege that a principal holds are revoked, so
+// other grants on the directory are left alone. privilege.Principal is ignored.
+//
+// Parameters:
+//
+//	privilege: A DirectoryPrivilege struct containing the details of the privileges to be revoked.
+//	principals: The users or roles from whom the privileges should be revoked.
+//
+// Returns:
+//
+//	An error if a revoke operation fails.
+func (c *Client) RevokeDirectoryPrivilegesFromPrincipals(privilege DirectoryPrivilege, principals []string) error {
+	for _, principal := range principals {
+		current, err := c.GetCurrentDirectoryPrivileges(principal, privilege.Directory, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		for _, priv := range privilege.Privileges {
+			if _, found := FindPrivilege(current, priv.Name); !found {
+				continue
+			}
+			revokeSQL := fmt.Sprintf("REVOKE %s ON DIRECTORY %s FROM %s%s", priv.Name, privilege.Directory, principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PlanForPrincipals combines the grant plans of several principals. Each
+// entry names its principal, e.g. "SELECT TO APP_READER" or
+// "SELECT FROM APP_WRITER".
+//
+// Parameters:
+//
+//	principals: The users or roles whose grants are planned.
+//	plan: A function returning the grant plan of a single principal.
+//
+// Returns:
+//
+//	A GrantPlan with the grants to add and revoke, and an error if a plan cannot be created.
+func PlanForPrincipals(principals []string, plan func(principal string) (GrantPlan, error)) (GrantPlan, error) {
+	combined := GrantPlan{ToGrant: []string{}, ToRevoke: []string{}}
+	for _, principal := range principals {
+		principalPlan, err := plan(principal)
+		if err != nil {
+			return GrantPlan{}, err
+		}
+		for _, grant := range principalPlan.ToGrant {
+			combined.ToGrant = append(combined.ToGrant, fmt.Sprintf("%s TO %s", grant, strings.ToUpper(principal)))
+		}
+		for _, revoke := range principalPlan.ToRevoke {
+			combined.ToRevoke = append(combined.ToRevoke, fmt.Sprintf("%s FROM %s", revoke, strings.ToUpper(principal)))
+		}
+	}
+	sort.Strings(combined.ToGrant)
+	sort.Strings(combined.ToRevoke)
+	return combined, nil
+}
+
+// groupPrincipals groups principals by the privileges and column privileges
+// they are missing. Principals missing nothing are left out, and groups keep
+// the order of principals.
+func groupPrincipals(principals []string, toGrant map[string][]Privilege, columnsToGrant map[string]map[string][]string) []principalGroup {
+	var groups []principalGroup
+	index := map[string]int{}
+	for _, principal := range principals {
+		privileges, columns := toGrant[principal], columnsToGrant[principal]
+		if len(privileges) == 0 && len(columns) == 0 {
+			continue
+		}
+
+		var key []string
+		for _, privilege := range privileges {
+			key = append(key, privilege.String())
+		}
+		for priv, privColumns := range columns {
+			sorted := append([]string{}, privColumns...)
+			sort.Strings(sorted)
+			key = append(key, fmt.Sprintf("%s (%s)", strings.ToUpper(priv), strings.ToUpper(strings.Join(sorted, ","))))
+		}
+		sort.Strings(key)
+
+		if i, found := index[strings.Join(key, ";")]; found {
+			groups[i].principals = append(groups[i].principals, principal)
+			continue
+		}
+		index[strings.Join(key, ";")] = len(groups)
+		groups = append(groups, principalGroup{principals: []string{principal}, privileges: privileges, columns: columns})
+	}
+	return groups
+}
+
+// execGrants grants privileges to several principals, in one statement per
+// combination of WITH ... OPTION clauses. on is the ON clause of the
+// statement, or an empty string for system privileges.
+func (c *Client) execGrants(privileges []Privilege, on string, principals []string, containerScope string) error {
+	var options []string
+	names := map[string][]string{}
+	for _, privilege := range privileges {
+		if _, found := names[privilege.options()]; !found {
+			options = append(options, privilege.options())
+		}
+		names[privilege.options()] = append(names[privilege.options()], privilege.Name)
+	}
+
+	for _, option := range options {
+		grantSQL := fmt.Sprintf("GRANT %s%s TO %s%s%s", strings.Join(names[option], ","), on, strings.Join(principals, ", "), option, containerClause(containerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestGrantToPrincipals(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	principals := []string{"testprincipalsa", "testprincipalsb"}
+	for _, principal := range principals {
+		exists, err := client.RoleExists(principal)
+		assert.NoError(t, err)
+		if exists {
+			assert.NoError(t, client.DropRole(principal))
+		}
+		assert.NoError(t, client.CreateRole(oracle.Role{Name: principal, AuthenticationType: "none"}))
+		defer func() {
+			assert.NoError(t, client.DropRole(principal))
+		}()
+	}
+
+	_, err = client.ExecuteSQL("CREATE TABLE system.test_principals_table (id NUMBER, name VARCHAR2(30))")
+	assert.NoError(t, err)
+	defer func() {
+		_, err := client.ExecuteSQL("DROP TABLE system.test_principals_table")
+		assert.NoError(t, err)
+	}()
+
+	// The second principal already holds a privilege that enforce mode revokes
+	_, err = client.ExecuteSQL("GRANT CREATE VIEW TO testprincipalsb")
+	assert.NoError(t, err)
+
+	systemGrant := oracle.Grant{
+		Privileges: []oracle.Privilege{{Name: "CREATE SESSION"}, {Name: "CREATE TABLE", AdminOption: true}},
+		GrantsMode: "enforce",
+	}
+	assert.NoError(t, client.GrantSystemPrivilegesToPrincipals(systemGrant, principals))
+
+	for _, principal := range principals {
+		privileges, err := client.GetCurrentSystemPrivileges(principal, "current")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []oracle.Privilege{{Name: "CREATE SESSION"}, {Name: "CREATE TABLE", AdminOption: true}}, privileges)
+	}
+
+	// Revoking only removes the given privileges, and does not refuse
+	// Oracle-maintained principals
+	revokeGrant := oracle.Grant{Privileges: []oracle.Privilege{{Name: "CREATE TABLE"}}}
+	assert.NoError(t, client.RevokeSystemPrivilegesFromPrincipals(revokeGrant, append(principals, "select_catalog_role")))
+	for _, principal := range principals {
+		privileges, err := client.GetCurrentSystemPrivileges(principal, "current")
+		assert.NoError(t, err)
+		assert.Equal(t, []oracle.Privilege{{Name: "CREATE SESSION"}}, privileges)
+	}
+
+	objectGrant := oracle.ObjectPrivilege{
+		Owner:            "system",
+		Object:           "test_principals_table",
+		Privileges:       []oracle.Privilege{{Name: "SELECT", GrantOption: true}},
+		ColumnPrivileges: map[string][]string{"UPDATE": {"name"}},
+		GrantsMode:       "append",
+	}
+	assert.NoError(t, client.GrantObjectPrivilegesToPrincipals(objectGrant, principals))
+
+	plan, err := oracle.PlanForPrincipals(principals, func(principal string) (oracle.GrantPlan, error) {
+		objectGrant.Principal = principal
+		objectGrant.Privileges = []oracle.Privilege{{Name: "SELECT", GrantOption: true}, {Name: "INSERT"}}
+		return client.PlanObjectPrivileges(objectGrant)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"INSERT TO TESTPRINCIPALSA", "INSERT TO TESTPRINCIPALSB"}, plan.ToGrant)
+	assert.Empty(t, plan.ToRevoke)
+
+	for _, principal := range principals {
+		columns, err := client.GetCurrentColumnPrivileges(principal, "system", "test_principals_table", "current")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"UPDATE": {"NAME"}}, columns)
+	}
+
+	// Removing a column revokes UPDATE from the table and all columns, so the
+	// table-level UPDATE and the remaining column are granted again
+	objectGrant.Privileges = []oracle.Privilege{{Name: "SELECT", GrantOption: true}, {Name: "UPDATE"}}
+	objectGrant.ColumnPrivileges = map[string][]string{"UPDATE": {"id", "name"}}
+	assert.NoError(t, client.GrantObjectPrivilegesToPrincipals(objectGrant, principals))
+	objectGrant.ColumnPrivileges = map[string][]string{"UPDATE": {"id"}}
+	objectGrant.GrantsMode = "enforce"
+	assert.NoError(t, client.GrantObjectPrivilegesToPrincipals(objectGrant, principals))
+
+	for _, principal := range principals {
+		privileges, err := client.GetCurrentObjectPrivileges(principal, "system", "test_principals_table", "", "current")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []oracle.Privilege{{Name: "SELECT", GrantOption: true}, {Name: "UPDATE"}}, privileges)
+		columns, err := client.GetCurrentColumnPrivileges(principal, "system", "test_principals_table", "current")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"UPDATE": {"ID"}}, columns)
+	}
+
+	// Enforce mode refuses to revoke object privileges of Oracle-maintained principals
+	maintainedGrant := objectGrant
+	maintainedGrant.Privileges = []oracle.Privilege{}
+	maintainedGrant.ColumnPrivileges = nil
+	err = client.GrantObjectPrivilegesToPrincipals(maintainedGrant, []string{"select_catalog_role"})
+	assert.ErrorContains(t, err, "Oracle-maintained principal SELECT_CATALOG_ROLE")
+
+	// Revoking only removes the given privileges
+	objectGrant.Privileges = []oracle.Privilege{{Name: "UPDATE"}}
+	objectGrant.ColumnPrivileges = map[string][]string{"UPDATE": {"id"}}
+	assert.NoError(t, client.RevokeObjectPrivilegesFromPrincipals(objectGrant, principals))
+	for _, principal := range principals {
+		privileges, err := client.GetCurrentObjectPrivileges(principal, "system", "test_principals_table", "", "current")
+		assert.NoError(t, err)
+		assert.Equal(t, []oracle.Privilege{{Name: "SELECT", GrantOption: true}}, privileges)
+		columns, err := client.GetCurrentColumnPrivileges(principal, "system", "test_principals_table", "current")
+		assert.NoError(t, err)
+		assert.Empty(t, columns)
+	}
+
+	_, err = client.ExecuteSQL("CREATE OR REPLACE DIRECTORY test_principals_dir AS '/tmp'")
+	assert.NoError(t, err)
+	defer func() {
+		_, err := client.ExecuteSQL("DROP DIRECTORY test_principals_dir")
+		assert.NoError(t, err)
+	}()
+
+	directoryGrant := oracle.DirectoryPrivilege{
+		Directory:  "test_principals_dir",
+		Privileges: []oracle.Privilege{{Name: "READ"}, {Name: "WRITE"}},
+		GrantsMode: "enforce",
+	}
+	assert.NoError(t, client.GrantDirectoryPrivilegesToPrincipals(directoryGrant, principals))
+
+	// Enforce mode refuses to revoke directory privileges of Oracle-maintained principals
+	err = client.GrantDirectoryPrivilegesToPrincipals(oracle.DirectoryPrivilege{
+		Directory:  "test_principals_dir",
+		Privileges: []oracle.Privilege{},
+		GrantsMode: "enforce",
+	}, []string{"select_catalog_role"})
+	assert.ErrorContains(t, err, "Oracle-maintained principal SELECT_CATALOG_ROLE")
+
+	// Revoking only removes the given privileges
+	directoryGrant.Privileges = []oracle.Privilege{{Name: "WRITE"}}
+	assert.NoError(t, client.RevokeDirectoryPrivilegesFromPrincipals(directoryGrant, principals))
+	for _, principal := range principals {
+		privileges, err := client.GetCurrentDirectoryPrivileges(principal, "test_principals_dir", "current")
+		assert.NoError(t, err)
+		assert.Equal(t, []oracle.Privilege{{Name: "READ"}}, privileges)
+	}
+}
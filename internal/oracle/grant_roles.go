@@ -5,18 +5,35 @@ package oracle
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
 // GrantRole represents a role to be granted to a user.
 type GrantRole struct {
-	Principal  string   // The user to whom the roles should be granted.
-	Roles      []string // A list of roles to grant.
-	GrantsMode string   // The grant mode, either "enforce" or "append".
+	Principal             string   // The user to whom the roles should be granted.
+	Roles                 []string // A list of roles to grant.
+	AdminRoles            []string // The subset of Roles to grant WITH ADMIN OPTION.
+	DefaultRoles          []string // The subset of Roles enabled by default at login. A nil slice leaves the default roles unchanged.
+	GrantsMode            string   // The grant mode, either "enforce" or "append".
+	ContainerScope        string   // The container scope, either "current" or "all" for common grants in a CDB.
+	Ignore                []string // Patterns of roles that enforce mode does not revoke.
+	AllowOracleMaintained bool     // Whether enforce mode may revoke roles of Oracle-maintained principals.
+	Managed               []string // For PUBLIC, the roles that enforce mode may revoke, i.e. those granted by the provider.
+}
+
+// RoleGrant represents a role currently granted to a user or role, as read from dba_role_privs.
+type RoleGrant struct {
+	Role        string // The name of the granted role in lowercase.
+	AdminOption bool   // Whether the role was granted WITH ADMIN OPTION.
+	Default     bool   // Whether the role is enabled by default at login.
 }
 
 // GrantRoles grants roles to a user.
 //
+// Roles whose ADMIN OPTION should be removed are revoked and granted again,
+// as Oracle has no way to revoke only the ADMIN OPTION of a role.
+//
 // Parameters:
 //
 //	grant: A GrantRole struct containing the details of the roles to be granted.
@@ -26,39 +43,93 @@ type GrantRole struct {
 //	An error if the grant operation fails.
 func (c *Client) GrantRoles(grant GrantRole) error {
 	if grant.GrantsMode == "enforce" {
-		currentRoles, err := c.GetCurrentRoles(grant.Principal)
-		if err != nil {
+		if err := c.checkEnforcePrincipal(grant.Principal, grant.AllowOracleMaintained); err != nil {
 			return err
 		}
+	}
 
-		// Revoke roles that are not in the desired list
-		for _, currentRole := range currentRoles {
-			found := false
-			for _, desiredRole := range grant.Roles {
-				if strings.EqualFold(currentRole, desiredRole) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				revokeSQL := fmt.Sprintf("REVOKE %s FROM %s", currentRole, grant.Principal)
-				if _, err := c.DB.Exec(revokeSQL); err != nil {
-					return err
-				}
+	currentGrants, err := c.GetCurrentRoleGrants(grant.Principal, grant.ContainerScope)
+	if err != nil {
+		return err
+	}
+
+	for _, current := range currentGrants {
+		desired := ContainsFold(grant.Roles, current.Role)
+		// Revoke roles that are not in the desired list, only those granted by the provider for PUBLIC
+		revokeUnwanted := grant.GrantsMode == "enforce" && !desired && !IsIgnored(grant.Ignore, current.Role) &&
+			(!IsPublic(grant.Principal) || ContainsFold(grant.Managed, current.Role))
+		// Revoke roles that should lose their admin option so they can be granted again without it
+		revokeAdmin := desired && current.AdminOption && !ContainsFold(grant.AdminRoles, current.Role)
+		if revokeUnwanted || revokeAdmin {
+			revokeSQL := fmt.Sprintf("REVOKE %s FROM %s%s", current.Role, grant.Principal, containerClause(grant.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
 			}
 		}
 	}
 
 	// Grant the desired roles
-	if len(grant.Roles) > 0 {
-		roles := strings.Join(grant.Roles, ",")
-		grantSQL := fmt.Sprintf("GRANT %s TO %s", roles, grant.Principal)
-		_, err := c.DB.Exec(grantSQL)
-		return err
+	var roles, adminRoles []string
+	for _, role := range grant.Roles {
+		if ContainsFold(grant.AdminRoles, role) {
+			adminRoles = append(adminRoles, role)
+		} else {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) > 0 {
+		grantSQL := fmt.Sprintf("GRANT %s TO %s%s", strings.Join(roles, ","), grant.Principal, containerClause(grant.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+	if len(adminRoles) > 0 {
+		grantSQL := fmt.Sprintf("GRANT %s TO %s WITH ADMIN OPTION%s", strings.Join(adminRoles, ","), grant.Principal, containerClause(grant.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+
+	if grant.DefaultRoles != nil {
+		return c.SetDefaultRoles(grant)
 	}
 	return nil
 }
 
+// SetDefaultRoles enables the default roles of a user so that exactly the
+// roles in grant.DefaultRoles are enabled at login among grant.Roles. Roles
+// of the user that are not in grant.Roles keep their current default setting.
+//
+// Parameters:
+//
+//	grant: A GrantRole struct containing the managed roles and the default roles.
+//
+// Returns:
+//
+//	An error if the operation fails.
+func (c *Client) SetDefaultRoles(grant GrantRole) error {
+	currentGrants, err := c.GetCurrentRoleGrants(grant.Principal, grant.ContainerScope)
+	if err != nil {
+		return err
+	}
+
+	var except []string
+	for _, current := range currentGrants {
+		managed := ContainsFold(grant.Roles, current.Role)
+		if (managed && !ContainsFold(grant.DefaultRoles, current.Role)) || (!managed && !current.Default) {
+			except = append(except, current.Role)
+		}
+	}
+
+	sql := fmt.Sprintf("ALTER USER %s DEFAULT ROLE ALL", grant.Principal)
+	if len(except) > 0 {
+		sql += " EXCEPT " + strings.Join(except, ",")
+	}
+	sql += containerClause(grant.ContainerScope)
+	_, err = c.DB.Exec(sql)
+	return err
+}
+
 // RevokeRoles revokes roles from a user.
 //
 // Parameters:
@@ -71,7 +142,7 @@ func (c *Client) GrantRoles(grant GrantRole) error {
 func (c *Client) RevokeRoles(grant GrantRole) error {
 	if len(grant.Roles) > 0 {
 		roles := strings.Join(grant.Roles, ",")
-		revokeSQL := fmt.Sprintf("REVOKE %s FROM %s", roles, grant.Principal)
+		revokeSQL := fmt.Sprintf("REVOKE %s FROM %s%s", roles, grant.Principal, containerClause(grant.ContainerScope))
 		_, err := c.DB.Exec(revokeSQL)
 		return err
 	}
@@ -83,13 +154,38 @@ func (c *Client) RevokeRoles(grant GrantRole) error {
 // Parameters:
 //
 //	principal: The name of the user to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
 //
 // Returns:
 //
 //	A slice of strings containing the current roles and an error if the check fails.
-func (c *Client) GetCurrentRoles(principal string) ([]string, error) {
+func (c *Client) GetCurrentRoles(principal, containerScope string) ([]string, error) {
+	grants, err := c.GetCurrentRoleGrants(principal, containerScope)
+	if err != nil {
+		return nil, err
+	}
+
 	var roles []string
-	sql := "SELECT granted_role FROM dba_role_privs WHERE grantee = UPPER(:1)"
+	for _, grant := range grants {
+		roles = append(roles, grant.Role)
+	}
+	return roles, nil
+}
+
+// GetCurrentRoleGrants returns the current roles for a user together with
+// their admin option and default role flags.
+//
+// Parameters:
+//
+//	principal: The name of the user to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
+//
+// Returns:
+//
+//	A slice of RoleGrant structs for the current roles and an error if the check fails.
+func (c *Client) GetCurrentRoleGrants(principal, containerScope string) ([]RoleGrant, error) {
+	var grants []RoleGrant
+	sql := "SELECT granted_role, admin_option, default_role FROM dba_role_privs WHERE grantee = UPPER(:1)" + containerFilter(containerScope)
 	rows, err := c.DB.Query(sql, principal)
 	if err != nil {
 		return nil, err
@@ -97,11 +193,31 @@ func (c *Client) GetCurrentRoles(principal string) ([]string, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		var role string
-		if err := rows.Scan(&role); err != nil {
+		var role, adminOption, defaultRole string
+		if err := rows.Scan(&role, &adminOption, &defaultRole); err != nil {
 			return nil, err
 		}
-		roles = append(roles, strings.ToLower(role))
+		grants = append(grants, RoleGrant{
+			Role:        strings.ToLower(role),
+			AdminOption: adminOption == "YES",
+			Default:     defaultRole == "YES",
+		})
 	}
-	return roles, nil
+	return grants, nil
+}
+
+// ContainsFold checks if values contains value, ignoring case.
+//
+// Parameters:
+//
+//	values: The values to search.
+//	value: The value to find.
+//
+// Returns:
+//
+//	True if one of the values equals value, ignoring case.
+func ContainsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(v, value)
+	})
 }
@@ -46,15 +46,33 @@ func TestGrantRoles(t *testing.T) {
 	err = client.GrantRoles(grant)
 	assert.NoError(t, err)
 
-	currentRoles, err := client.GetCurrentRoles("test_user")
+	currentRoles, err := client.GetCurrentRoles("test_user", "current")
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"test_role"}, currentRoles)
 
+	// Grant the role WITH ADMIN OPTION and disable it by default
+	grant.AdminRoles = []string{"test_role"}
+	grant.DefaultRoles = []string{}
+	assert.NoError(t, client.GrantRoles(grant))
+
+	roleGrants, err := client.GetCurrentRoleGrants("test_user", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, []RoleGrant{{Role: "test_role", AdminOption: true, Default: false}}, roleGrants)
+
+	// Remove the admin option again and enable the role by default
+	grant.AdminRoles = nil
+	grant.DefaultRoles = []string{"test_role"}
+	assert.NoError(t, client.GrantRoles(grant))
+
+	roleGrants, err = client.GetCurrentRoleGrants("test_user", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, []RoleGrant{{Role: "test_role", AdminOption: false, Default: true}}, roleGrants)
+
 	grant.Roles = []string{"test_role"}
 	err = client.RevokeRoles(grant)
 	assert.NoError(t, err)
 
-	currentRoles, err = client.GetCurrentRoles("test_user")
+	currentRoles, err = client.GetCurrentRoles("test_user", "current")
 	assert.NoError(t, err)
 	assert.Empty(t, currentRoles)
 }
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+)
+
+// SchemaPrivilege represents privileges granted on all objects of a schema (Oracle 23ai and later).
+type SchemaPrivilege struct {
+	Principal      string      // The user or role to whom the privileges should be granted.
+	Schema         string      // The schema on which to grant the privileges.
+	Privileges     []Privilege // A list of schema privileges to grant, e.g. "SELECT ANY TABLE".
+	GrantsMode     string      // The grants mode, either "enforce" or "append".
+	ContainerScope string      // The container scope, either "current" or "all" for common grants in a CDB.
+	Managed        []string    // For PUBLIC, the privileges that enforce mode may revoke, i.e. those granted by the provider.
+}
+
+// GrantSchemaPrivileges grants schema privileges to a user or role.
+//
+// Parameters:
+//
+//	privilege: A SchemaPrivilege struct containing the details of the privileges to be granted.
+//
+// Returns:
+//
+//	An error if the database does not support schema privileges or the grant operation fails.
+func (c *Client) GrantSchemaPrivileges(privilege SchemaPrivilege) error {
+	if err := c.RequireVersion(23, 0, "schema privileges"); err != nil {
+		return err
+	}
+
+	if privilege.GrantsMode == "enforce" {
+		currentPrivs, err := c.GetCurrentSchemaPrivileges(privilege.Principal, privilege.Schema, privilege.ContainerScope)
+		if err != nil {
+			return err
+		}
+
+		// Revoke privileges that are not in the desired list, or that hold an
+		// admin option that is no longer desired
+		_, toRevoke := diffPrivileges(currentPrivs, privilege.Privileges, true, nil)
+		for _, priv := range revocable(privilege.Principal, toRevoke, privilege.Managed) {
+			revokeSQL := fmt.Sprintf("REVOKE %s ON SCHEMA %s FROM %s%s", priv.Name, privilege.Schema, privilege.Principal, containerClause(privilege.ContainerScope))
+			if _, err := c.DB.Exec(revokeSQL); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Grant the desired privileges
+	for _, priv := range privilege.Privileges {
+		grantSQL := fmt.Sprintf("GRANT %s ON SCHEMA %s TO %s%s%s", priv.Name, privilege.Schema, privilege.Principal, priv.options(), containerClause(privilege.ContainerScope))
+		if _, err := c.DB.Exec(grantSQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCurrentSchemaPrivileges returns the current schema privileges of a user or role on a schema.
+//
+// Parameters:
+//
+//	principal: The name of the user or role to check.
+//	schema: The name of the schema to check.
+//	containerScope: The container scope of the grants to return, either "current" or "all".
+//
+// Returns:
+//
+//	A slice of Privilege structs containing the current schema privileges, and an error if the check fails.
+func (c *Client) GetCurrentSchemaPrivileges(principal, schema, containerScope string) ([]Privilege, error) {
+	if err := c.RequireVersion(23, 0, "schema privileges"); err != nil {
+		return nil, err
+	}
+
+	var privileges []Privilege
+	sql := "SELECT privilege, admin_option FROM dba_schema_privs WHERE grantee = UPPER(:1) AND schema = UPPER(:2)" + containerFilter(containerScope)
+	rows, err := c.DB.Query(sql, principal, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var privilege string
+		var adminOption string
+		if err := rows.Scan(&privilege, &adminOption); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, Privilege{Name: privilege, AdminOption: adminOption == "YES"})
+	}
+	return privileges, nil
+}
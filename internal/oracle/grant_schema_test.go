@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestGrantSchemaPrivileges(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	if err := client.RequireVersion(23, 0, "schema privileges"); err != nil {
+		t.Skip(err)
+	}
+
+	testUser := oracle.User{
+		Username:           "testschemagrantuser",
+		Password:           "testpassword",
+		AuthenticationType: "password",
+	}
+
+	exists, err := client.UserExists(testUser.Username)
+	assert.NoError(t, err)
+	if exists {
+		assert.NoError(t, client.DropUser(testUser.Username, true))
+	}
+	assert.NoError(t, client.CreateUser(testUser))
+	defer func() {
+		assert.NoError(t, client.DropUser(testUser.Username, true))
+	}()
+
+	schemaGrant := oracle.SchemaPrivilege{
+		Principal:  testUser.Username,
+		Schema:     "system",
+		Privileges: []oracle.Privilege{{Name: "SELECT ANY TABLE"}},
+		GrantsMode: "enforce",
+	}
+	assert.NoError(t, client.GrantSchemaPrivileges(schemaGrant))
+
+	_, err = client.ExecuteSQL("GRANT EXECUTE ANY PROCEDURE ON SCHEMA system TO " + testUser.Username)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.GrantSchemaPrivileges(schemaGrant))
+
+	privileges, err := client.GetCurrentSchemaPrivileges(testUser.Username, "system", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT ANY TABLE"}}, privileges)
+
+	schemaGrant.Privileges = []oracle.Privilege{}
+	assert.NoError(t, client.GrantSchemaPrivileges(schemaGrant))
+
+	privileges, err = client.GetCurrentSchemaPrivileges(testUser.Username, "system", "current")
+	assert.NoError(t, err)
+	assert.Empty(t, privileges)
+}
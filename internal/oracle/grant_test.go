@@ -41,7 +41,7 @@ func TestGrant(t *testing.T) {
 	exists, err := client.UserExists(testUser.Username)
 	assert.NoError(t, err)
 	if exists {
-		assert.NoError(t, client.DropUser(testUser.Username))
+		assert.NoError(t, client.DropUser(testUser.Username, true))
 	}
 
 	assert.NoError(t, client.CreateUser(testUser))
@@ -54,7 +54,7 @@ func TestGrant(t *testing.T) {
 
 	systemGrant := oracle.Grant{
 		Principal:  testUser.Username,
-		Privileges: []string{"CREATE SESSION"},
+		Privileges: []oracle.Privilege{{Name: "CREATE SESSION"}},
 		GrantsMode: "enforce",
 	}
 	assert.NoError(t, client.GrantSystemPrivileges(systemGrant))
@@ -67,7 +67,7 @@ func TestGrant(t *testing.T) {
 	objectGrant := oracle.ObjectPrivilege{
 		Principal:  testUser.Username,
 		Object:     "system.test_table",
-		Privileges: []string{"SELECT"},
+		Privileges: []oracle.Privilege{{Name: "SELECT"}},
 		GrantsMode: "enforce",
 	}
 	assert.NoError(t, client.GrantObjectPrivileges(objectGrant))
@@ -77,10 +77,71 @@ func TestGrant(t *testing.T) {
 
 	assert.NoError(t, client.GrantObjectPrivileges(objectGrant))
 
+	columnGrant := oracle.ObjectPrivilege{
+		Principal:        testUser.Username,
+		Owner:            "system",
+		Object:           "test_table",
+		ColumnPrivileges: map[string][]string{"UPDATE": {"id"}},
+		GrantsMode:       "enforce",
+	}
+	assert.NoError(t, client.GrantObjectPrivileges(columnGrant))
+
+	columnPrivs, err := client.GetCurrentColumnPrivileges(testUser.Username, "system", "test_table", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"UPDATE": {"ID"}}, columnPrivs)
+
+	columnGrant.ColumnPrivileges = nil
+	assert.NoError(t, client.GrantObjectPrivileges(columnGrant))
+
+	columnPrivs, err = client.GetCurrentColumnPrivileges(testUser.Username, "system", "test_table", "current")
+	assert.NoError(t, err)
+	assert.Empty(t, columnPrivs)
+
+	_, err = client.ExecuteSQL("CREATE SEQUENCE system.test_seq")
+	assert.NoError(t, err)
+
+	exists, err = client.ObjectExists("system", "test_seq", "TABLE")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	sequenceGrant := oracle.ObjectPrivilege{
+		Principal:  testUser.Username,
+		Owner:      "system",
+		Object:     "test_seq",
+		ObjectType: "SEQUENCE",
+		Privileges: []oracle.Privilege{{Name: "SELECT"}},
+		GrantsMode: "enforce",
+	}
+	assert.NoError(t, client.GrantObjectPrivileges(sequenceGrant))
+
+	privileges, err := client.GetCurrentObjectPrivileges(testUser.Username, "system", "test_seq", "SEQUENCE", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT"}}, privileges)
+
+	privileges, err = client.GetCurrentObjectPrivileges(testUser.Username, "system", "test_seq", "TABLE", "current")
+	assert.NoError(t, err)
+	assert.Empty(t, privileges)
+
+	editionGrant := oracle.ObjectPrivilege{
+		Principal:  testUser.Username,
+		Object:     "ora$base",
+		ObjectType: "EDITION",
+		Privileges: []oracle.Privilege{{Name: "USE"}},
+		GrantsMode: "enforce",
+	}
+	assert.NoError(t, client.GrantObjectPrivileges(editionGrant))
+
+	privileges, err = client.GetCurrentObjectPrivileges(testUser.Username, "", "ora$base", "EDITION", "current")
+	assert.NoError(t, err)
+	assert.Equal(t, []oracle.Privilege{{Name: "USE"}}, privileges)
+
+	editionGrant.Privileges = []oracle.Privilege{}
+	assert.NoError(t, client.GrantObjectPrivileges(editionGrant))
+
 	directoryGrant := oracle.DirectoryPrivilege{
 		Principal:  testUser.Username,
 		Directory:  "test_dir",
-		Privileges: []string{"READ"},
+		Privileges: []oracle.Privilege{{Name: "READ"}},
 		GrantsMode: "enforce",
 	}
 	assert.NoError(t, client.GrantDirectoryPrivileges(directoryGrant))
@@ -93,8 +154,11 @@ func TestGrant(t *testing.T) {
 	_, err = client.ExecuteSQL("DROP TABLE system.test_table")
 	assert.NoError(t, err)
 
+	_, err = client.ExecuteSQL("DROP SEQUENCE system.test_seq")
+	assert.NoError(t, err)
+
 	_, err = client.ExecuteSQL("DROP DIRECTORY test_dir")
 	assert.NoError(t, err)
 
-	assert.NoError(t, client.DropUser(testUser.Username))
+	assert.NoError(t, client.DropUser(testUser.Username, true))
 }
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// HostACE represents the access control entry of a principal for a network
+// host, managed with DBMS_NETWORK_ACL_ADMIN.
+type HostACE struct {
+	Host          string   // The host name, domain (e.g. "*.example.com") or IP address.
+	LowerPort     int      // The lower bound of the port range, or 0 for all ports.
+	UpperPort     int      // The upper bound of the port range, or 0 for all ports.
+	Principal     string   // The user or role to whom the privileges are granted.
+	PrincipalType string   // The type of the principal: "database" or "xs" for Real Application Security principals.
+	Privileges    []string // The network privileges, e.g. "connect" or "resolve".
+}
+
+// WalletACE represents the access control entry of a principal for a wallet,
+// managed with DBMS_NETWORK_ACL_ADMIN.
+type WalletACE struct {
+	WalletPath    string   // The path of the wallet, e.g. "file:/u01/app/oracle/wallet".
+	Principal     string   // The user or role to whom the privileges are granted.
+	PrincipalType string   // The type of the principal: "database" or "xs" for Real Application Security principals.
+	Privileges    []string // The wallet privileges, e.g. "use_client_certificates" or "use_passwords".
+}
+
+// AppendHostACE grants network privileges on a host to a principal.
+//
+// Parameters:
+//
+//	ace: A HostACE struct containing the host, ports, principal and privileges to grant.
+//
+// Returns:
+//
+//	An error if the grant fails.
+func (c *Client) AppendHostACE(ace HostACE) error {
+	return c.execHostACE("APPEND_HOST_ACE", ace, "")
+}
+
+// RemoveHostACE revokes network privileges on a host from a principal. The
+// access control list of the host is dropped once it is empty.
+//
+// Parameters:
+//
+//	ace: A HostACE struct containing the host, ports, principal and privileges to revoke.
+//
+// Returns:
+//
+//	An error if the revoke fails.
+func (c *Client) RemoveHostACE(ace HostACE) error {
+	return c.execHostACE("REMOVE_HOST_ACE", ace, ", remove_empty_acl => TRUE")
+}
+
+// GetHostACEPrivileges reads the network privileges granted to a principal on
+// a host and port range from dba_host_aces.
+//
+// Parameters:
+//
+//	ace: A HostACE struct identifying the host, ports and principal. Privileges is ignored.
+//
+// Returns:
+//
+//	The privileges in lowercase, and an error if the read fails.
+func (c *Client) GetHostACEPrivileges(ace HostACE) ([]string, error) {
+	query := "SELECT DISTINCT LOWER(privilege) FROM dba_host_aces" +
+		" WHERE LOWER(host) = LOWER(:1) AND NVL(lower_port, 0) = :2 AND NVL(upper_port, 0) = :3" +
+		" AND principal = :4 AND UPPER(principal_type) = UPPER(:5) AND grant_type = 'GRANT'" +
+		" ORDER BY 1"
+	return c.queryACEPrivileges(query, ace.Host, ace.LowerPort, ace.UpperPort, aclPrincipal(ace.Principal, ace.PrincipalType), ace.PrincipalType)
+}
+
+// AppendWalletACE grants privileges on a wallet to a principal.
+//
+// Parameters:
+//
+//	ace: A WalletACE struct containing the wallet path, principal and privileges to grant.
+//
+// Returns:
+//
+//	An error if the grant fails.
+func (c *Client) AppendWalletACE(ace WalletACE) error {
+	return c.execWalletACE("APPEND_WALLET_ACE", ace, "")
+}
+
+// RemoveWalletACE revokes privileges on a wallet from a principal. The access
+// control list of the wallet is dropped once it is empty.
+//
+// Parameters:
+//
+//	ace: A WalletACE struct containing the wallet path, principal and privileges to revoke.
+//
+// Returns:
+//
+//	An error if the revoke fails.
+func (c *Client) RemoveWalletACE(ace WalletACE) error {
+	return c.execWalletACE("REMOVE_WALLET_ACE", ace, ", remove_empty_acl => TRUE")
+}
+
+// GetWalletACEPrivileges reads the privileges granted to a principal on a
+// wallet from dba_wallet_aces.
+//
+// Parameters:
+//
+//	ace: A WalletACE struct identifying the wallet and principal. Privileges is ignored.
+//
+// Returns:
+//
+//	The privileges in lowercase, and an error if the read fails.
+func (c *Client) GetWalletACEPrivileges(ace WalletACE) ([]string, error) {
+	query := "SELECT DISTINCT LOWER(privilege) FROM dba_wallet_aces" +
+		" WHERE wallet_path = :1 AND principal = :2 AND UPPER(principal_type) = UPPER(:3) AND grant_type = 'GRANT'" +
+		" ORDER BY 1"
+	return c.queryACEPrivileges(query, ace.WalletPath, aclPrincipal(ace.Principal, ace.PrincipalType), ace.PrincipalType)
+}
+
+// execHostACE calls a DBMS_NETWORK_ACL_ADMIN procedure taking a host, a port
+// range and an access control entry.
+func (c *Client) execHostACE(procedure string, ace HostACE, options string) error {
+	block := fmt.Sprintf("BEGIN DBMS_NETWORK_ACL_ADMIN.%s(host => :1, lower_port => :2, upper_port => :3, ace => %s%s); END;",
+		procedure, aceConstructor(ace.Privileges, ace.PrincipalType, ":4"), options)
+	_, err := c.DB.Exec(block, ace.Host, aclPort(ace.LowerPort), aclPort(ace.UpperPort), aclPrincipal(ace.Principal, ace.PrincipalType))
+	return err
+}
+
+// execWalletACE calls a DBMS_NETWORK_ACL_ADMIN procedure taking a wallet path
+// and an access control entry.
+func (c *Client) execWalletACE(procedure string, ace WalletACE, options string) error {
+	block := fmt.Sprintf("BEGIN DBMS_NETWORK_ACL_ADMIN.%s(wallet_path => :1, ace => %s%s); END;",
+		procedure, aceConstructor(ace.Privileges, ace.PrincipalType, ":2"), options)
+	_, err := c.DB.Exec(block, ace.WalletPath, aclPrincipal(ace.Principal, ace.PrincipalType))
+	return err
+}
+
+// queryACEPrivileges runs a query returning one privilege per row.
+func (c *Client) queryACEPrivileges(query string, args ...any) ([]string, error) {
+	rows, err := c.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []string
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, privilege)
+	}
+	return privileges, rows.Err()
+}
+
+// aceConstructor returns the XS$ACE_TYPE constructor of an access control
+// entry whose principal name is bound to the given placeholder. The privileges
+// are validated by the resources and written as literals.
+func aceConstructor(privileges []string, principalType, principalBind string) string {
+	names := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		names[i] = fmt.Sprintf("'%s'", strings.ToLower(privilege))
+	}
+	ptype := "XS_ACL.PTYPE_DB"
+	if strings.EqualFold(principalType, "xs") {
+		ptype = "XS_ACL.PTYPE_XS"
+	}
+	return fmt.Sprintf("XS$ACE_TYPE(privilege_list => XS$NAME_LIST(%s), principal_name => %s, principal_type => %s)", strings.Join(names, ", "), principalBind, ptype)
+}
+
+// aclPrincipal returns the principal name as stored in access control
+// entries. Database users and roles are stored in uppercase, while Real
+// Application Security principals are case-sensitive.
+func aclPrincipal(principal, principalType string) string {
+	if strings.EqualFold(principalType, "xs") {
+		return principal
+	}
+	return strings.ToUpper(principal)
+}
+
+// aclPort returns the bind value of a port, where 0 means no port.
+func aclPort(port int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(port), Valid: port != 0}
+}
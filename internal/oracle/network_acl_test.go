@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestHostACE(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	_, err = client.DB.Exec("CREATE USER test_acl_user IDENTIFIED BY MyPassword123")
+	assert.NoError(t, err)
+	defer client.DB.Exec("DROP USER test_acl_user")
+
+	ace := oracle.HostACE{
+		Host:          "api.example.com",
+		LowerPort:     443,
+		UpperPort:     443,
+		Principal:     "test_acl_user",
+		PrincipalType: "database",
+		Privileges:    []string{"connect", "resolve"},
+	}
+	err = client.AppendHostACE(ace)
+	assert.NoError(t, err)
+
+	privileges, err := client.GetHostACEPrivileges(ace)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"connect", "resolve"}, privileges)
+
+	// An ACE on the same host for all ports is a different entry
+	allPorts := ace
+	allPorts.LowerPort, allPorts.UpperPort = 0, 0
+	privileges, err = client.GetHostACEPrivileges(allPorts)
+	assert.NoError(t, err)
+	assert.Empty(t, privileges)
+
+	ace.Privileges = []string{"resolve"}
+	err = client.RemoveHostACE(ace)
+	assert.NoError(t, err)
+
+	privileges, err = client.GetHostACEPrivileges(ace)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"connect"}, privileges)
+
+	ace.Privileges = []string{"connect"}
+	err = client.RemoveHostACE(ace)
+	assert.NoError(t, err)
+
+	privileges, err = client.GetHostACEPrivileges(ace)
+	assert.NoError(t, err)
+	assert.Empty(t, privileges)
+}
+
+func TestWalletACE(t *testing.T) {
+	dbUser := os.Getenv("ORACLE_USERNAME")
+	dbPassword := os.Getenv("ORACLE_PASSWORD")
+	dbHost := os.Getenv("ORACLE_HOST")
+	dbPortStr := os.Getenv("ORACLE_PORT")
+	dbServiceName := os.Getenv("ORACLE_SERVICE")
+
+	dbPort, err := strconv.Atoi(dbPortStr)
+	if err != nil {
+		log.Fatalf("Error converting port to integer: %v", err)
+	}
+
+	client, err := oracle.NewClient(dbHost, dbServiceName, dbUser, dbPassword, dbPort)
+	if err != nil {
+		log.Fatalf("Error creating Oracle client: %v", err)
+	}
+	defer client.DB.Close()
+
+	_, err = client.DB.Exec("CREATE USER test_wallet_user IDENTIFIED BY MyPassword123")
+	assert.NoError(t, err)
+	defer client.DB.Exec("DROP USER test_wallet_user")
+
+	ace := oracle.WalletACE{
+		WalletPath:    "file:/tmp/test_wallet",
+		Principal:     "test_wallet_user",
+		PrincipalType: "database",
+		Privileges:    []string{"use_client_certificates"},
+	}
+	err = client.AppendWalletACE(ace)
+	assert.NoError(t, err)
+
+	privileges, err := client.GetWalletACEPrivileges(ace)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"use_client_certificates"}, privileges)
+
+	err = client.RemoveWalletACE(ace)
+	assert.NoError(t, err)
+
+	privileges, err = client.GetWalletACEPrivileges(ace)
+	assert.NoError(t, err)
+	assert.Empty(t, privileges)
+}
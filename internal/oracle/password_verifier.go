@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// passwordVerifierLengths maps the prefix of each entry in a password verifier
+// to the number of hexadecimal characters of its value.
+var passwordVerifierLengths = map[string]int{
+	"S": 60,  // 11g SHA-1 verifier: 40 hash + 20 salt characters.
+	"T": 160, // 12c PBKDF2-based SHA-512 verifier: 128 hash + 32 salt characters.
+	"H": 32,  // HTTP digest verifier.
+}
+
+// legacyPasswordVerifierLength is the length of the 10g DES-based verifier stored in sys.user$.password.
+const legacyPasswordVerifierLength = 16
+
+var hexadecimal = regexp.MustCompile(`^[0-9A-F]+$`)
+
+// PasswordVerifier holds the password verifiers of a user as stored in sys.user$.
+type PasswordVerifier struct {
+	Username string // The name of the user.
+	Verifier string // The S: and T: entries joined with ";", usable with IDENTIFIED BY VALUES.
+	SHA1     string // The 11g S: verifier, or empty if the user has none.
+	SHA512   string // The 12c T: verifier, or empty if the user has none.
+}
+
+// ValidatePasswordVerifier checks that a password verifier can be used with
+// IDENTIFIED BY VALUES. It accepts the "S:...;T:...;H:..." format of
+// sys.user$.spare4 and the legacy 16 character verifier of sys.user$.password.
+//
+// Parameters:
+//
+//	verifier: The password verifier to check.
+//
+// Returns:
+//
+//	An error describing the first malformed entry, or nil if the verifier is valid.
+func ValidatePasswordVerifier(verifier string) error {
+	if verifier == "" {
+		return fmt.Errorf("password verifier must not be empty")
+	}
+	if len(verifier) == legacyPasswordVerifierLength && hexadecimal.MatchString(verifier) {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(verifier, ";") {
+		prefix, value, ok := strings.Cut(entry, ":")
+		length, known := passwordVerifierLengths[prefix]
+		if !ok || !known {
+			return fmt.Errorf("invalid password verifier entry %q, expected S:, T: or H: followed by a hexadecimal value", entry)
+		}
+		if len(value) != length || !hexadecimal.MatchString(value) {
+			return fmt.Errorf("invalid %s: password verifier, expected %d uppercase hexadecimal characters", prefix, length)
+		}
+		if seen[prefix] {
+			return fmt.Errorf("duplicate %s: entry in password verifier", prefix)
+		}
+		seen[prefix] = true
+	}
+	return nil
+}
+
+// ReadPasswordVerifier reads the password verifiers of a user from sys.user$.
+// This requires SELECT privileges on sys.user$.
+//
+// Parameters:
+//
+//	username: The name of the user to read.
+//
+// Returns:
+//
+//	A PasswordVerifier struct containing the user's verifiers, and an error if the read fails.
+func (c *Client) ReadPasswordVerifier(username string) (*PasswordVerifier, error) {
+	var name string
+	var spare4 sql.NullString
+	query := "SELECT name, spare4 FROM sys.user$ WHERE name = UPPER(:1) AND type# = 1"
+	if err := c.DB.QueryRow(query, username).Scan(&name, &spare4); err != nil {
+		return nil, err
+	}
+
+	verifier := &PasswordVerifier{Username: name}
+	var entries []string
+	for _, entry := range strings.Split(spare4.String, ";") {
+		switch {
+		case strings.HasPrefix(entry, "S:"):
+			verifier.SHA1 = entry
+			entries = append(entries, entry)
+		case strings.HasPrefix(entry, "T:"):
+			verifier.SHA512 = entry
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("user %s has no S: or T: password verifiers", name)
+	}
+	verifier.Verifier = strings.Join(entries, ";")
+	return verifier, nil
+}
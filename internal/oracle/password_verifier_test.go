@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestValidatePasswordVerifier(t *testing.T) {
+	s := "S:" + strings.Repeat("A1", 30)
+	tv := "T:" + strings.Repeat("B2", 80)
+	h := "H:" + strings.Repeat("C3", 16)
+
+	valid := []string{
+		s,
+		tv,
+		s + ";" + tv,
+		s + ";" + h + ";" + tv,
+		"F894844C34402B67",
+	}
+	for _, verifier := range valid {
+		assert.NoError(t, oracle.ValidatePasswordVerifier(verifier), verifier)
+	}
+
+	invalid := []string{
+		"",
+		"S:1234",
+		"X:" + strings.Repeat("A1", 30),
+		s + ";" + s,
+		strings.ToLower(s),
+		s + "';DROP USER system--",
+		"F894844C34402B6",
+	}
+	for _, verifier := range invalid {
+		assert.Error(t, oracle.ValidatePasswordVerifier(verifier), verifier)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"strings"
+)
+
+// Privilege represents a granted privilege together with its grant options.
+type Privilege struct {
+	Name            string // The name of the privilege, e.g. "CREATE SESSION" or "SELECT".
+	AdminOption     bool   // Whether the privilege is granted WITH ADMIN OPTION (system and schema privileges).
+	GrantOption     bool   // Whether the privilege is granted WITH GRANT OPTION (object and directory privileges).
+	HierarchyOption bool   // Whether the privilege is granted WITH HIERARCHY OPTION (SELECT on object tables and views).
+}
+
+// String returns the privilege in the form used by GRANT statements, e.g. "SELECT WITH GRANT OPTION".
+func (p Privilege) String() string {
+	return p.Name + p.options()
+}
+
+// options returns the WITH ... OPTION clauses of the privilege.
+func (p Privilege) options() string {
+	var options string
+	if p.HierarchyOption {
+		options += " WITH HIERARCHY OPTION"
+	}
+	if p.AdminOption {
+		options += " WITH ADMIN OPTION"
+	}
+	if p.GrantOption {
+		options += " WITH GRANT OPTION"
+	}
+	return options
+}
+
+// ParsePrivilege parses a privilege with optional WITH ADMIN, GRANT or
+// HIERARCHY OPTION suffixes, e.g. "SELECT WITH GRANT OPTION".
+//
+// Parameters:
+//
+//	privilege: The privilege to parse.
+//
+// Returns:
+//
+//	The parsed Privilege with an upper case name.
+func ParsePrivilege(privilege string) Privilege {
+	var p Privilege
+	name := strings.ToUpper(strings.TrimSpace(privilege))
+	for {
+		switch {
+		case strings.HasSuffix(name, " WITH ADMIN OPTION"):
+			p.AdminOption = true
+			name = strings.TrimSuffix(name, " WITH ADMIN OPTION")
+		case strings.HasSuffix(name, " WITH GRANT OPTION"):
+			p.GrantOption = true
+			name = strings.TrimSuffix(name, " WITH GRANT OPTION")
+		case strings.HasSuffix(name, " WITH HIERARCHY OPTION"):
+			p.HierarchyOption = true
+			name = strings.TrimSuffix(name, " WITH HIERARCHY OPTION")
+		default:
+			p.Name = name
+			return p
+		}
+	}
+}
+
+// FindPrivilege returns the privilege with the given name, ignoring case.
+//
+// Parameters:
+//
+//	privileges: The privileges to search.
+//	name: The name of the privilege to find.
+//
+// Returns:
+//
+//	The privilege, and true if it was found.
+func FindPrivilege(privileges []Privilege, name string) (Privilege, bool) {
+	for _, privilege := range privileges {
+		if strings.EqualFold(privilege.Name, name) {
+			return privilege, true
+		}
+	}
+	return Privilege{}, false
+}
+
+// privilegeNames returns the names of a list of privileges.
+func privilegeNames(privileges []Privilege) []string {
+	names := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		names[i] = privilege.Name
+	}
+	return names
+}
+
+// hasUndesiredOption checks if a current grant holds an option that the
+// desired privilege does not. Oracle cannot revoke an option alone, so such a
+// grant has to be revoked and granted again.
+func hasUndesiredOption(current, desired Privilege) bool {
+	return (current.AdminOption && !desired.AdminOption) ||
+		(current.GrantOption && !desired.GrantOption) ||
+		(current.HierarchyOption && !desired.HierarchyOption)
+}
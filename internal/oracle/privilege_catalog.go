@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// catalogFS holds the privilege names of SYSTEM_PRIVILEGE_MAP and
+// TABLE_PRIVILEGE_MAP for each supported major release, one name per line.
+//
+//go:embed catalog/*/*.txt
+var catalogFS embed.FS
+
+// PrivilegeKind is the kind of a privilege checked against a PrivilegeCatalog.
+type PrivilegeKind string
+
+const (
+	SystemPrivilegeKind    PrivilegeKind = "system"    // Privileges of SYSTEM_PRIVILEGE_MAP, e.g. CREATE SESSION.
+	ObjectPrivilegeKind    PrivilegeKind = "object"    // Privileges of TABLE_PRIVILEGE_MAP, e.g. SELECT.
+	DirectoryPrivilegeKind PrivilegeKind = "directory" // The object privileges that apply to directories.
+)
+
+// directoryPrivileges are the object privileges that can be granted on a directory.
+var directoryPrivileges = []string{"EXECUTE", "READ", "WRITE"}
+
+// PrivilegeCatalog holds the names of the privileges known to a release of Oracle.
+type PrivilegeCatalog struct {
+	Source string // Where the names come from, e.g. "embedded catalog for Oracle 19" or "live dictionary".
+	system map[string]bool
+	object map[string]bool
+}
+
+// newPrivilegeCatalog creates a catalog from lists of system and object privilege names.
+func newPrivilegeCatalog(source string, system, object []string) PrivilegeCatalog {
+	catalog := PrivilegeCatalog{Source: source, system: map[string]bool{}, object: map[string]bool{}}
+	for _, name := range system {
+		catalog.system[strings.ToUpper(name)] = true
+	}
+	for _, name := range object {
+		catalog.object[strings.ToUpper(name)] = true
+	}
+	return catalog
+}
+
+// Names returns the sorted names of the privileges of the given kind.
+func (c PrivilegeCatalog) Names(kind PrivilegeKind) []string {
+	var names []string
+	switch kind {
+	case SystemPrivilegeKind:
+		for name := range c.system {
+			names = append(names, name)
+		}
+	case ObjectPrivilegeKind:
+		for name := range c.object {
+			names = append(names, name)
+		}
+	case DirectoryPrivilegeKind:
+		for _, name := range directoryPrivileges {
+			if c.object[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check checks that a privilege of the given kind is part of the catalog.
+//
+// Parameters:
+//
+//	kind: The kind of the privilege.
+//	name: The name of the privilege, in any case.
+//
+// Returns:
+//
+//	An error naming the closest known privilege, if any, when the privilege is unknown.
+func (c PrivilegeCatalog) Check(kind PrivilegeKind, name string) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	names := c.Names(kind)
+	for _, known := range names {
+		if known == name {
+			return nil
+		}
+	}
+	if suggestion := closestName(names, name); suggestion != "" {
+		return fmt.Errorf("%q is not a known %s privilege, did you mean %q?", name, kind, suggestion)
+	}
+	return fmt.Errorf("%q is not a known %s privilege", name, kind)
+}
+
+// closestName returns the name closest to the given name by edit distance, or
+// an empty string if no name is close enough to be a likely typo.
+func closestName(names []string, name string) string {
+	closest := ""
+	maxDistance := len(name)/4 + 1
+	for _, candidate := range names {
+		if distance := editDistance(candidate, name); distance <= maxDistance {
+			closest = candidate
+			maxDistance = distance - 1
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// catalogReleases returns the major releases with an embedded catalog, in ascending order.
+func catalogReleases() []int {
+	entries, _ := catalogFS.ReadDir("catalog")
+	var releases []int
+	for _, entry := range entries {
+		if release, err := strconv.Atoi(entry.Name()); err == nil {
+			releases = append(releases, release)
+		}
+	}
+	sort.Ints(releases)
+	return releases
+}
+
+// readCatalogFile reads the privilege names of an embedded catalog file.
+func readCatalogFile(release int, name string) ([]string, error) {
+	file, err := catalogFS.Open(path.Join("catalog", strconv.Itoa(release), name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// embeddedCatalog reads the embedded catalog of a major release.
+func embeddedCatalog(release int) (PrivilegeCatalog, error) {
+	system, err := readCatalogFile(release, "system_privileges.txt")
+	if err != nil {
+		return PrivilegeCatalog{}, err
+	}
+	object, err := readCatalogFile(release, "object_privileges.txt")
+	if err != nil {
+		return PrivilegeCatalog{}, err
+	}
+	return newPrivilegeCatalog(fmt.Sprintf("embedded catalog for Oracle %d", release), system, object), nil
+}
+
+// EmbeddedPrivilegeCatalog returns the embedded catalog for a database
+// version, i.e. the catalog of the newest release that is not newer than the
+// version, or the oldest catalog for versions older than every embedded one.
+//
+// Parameters:
+//
+//	version: The version of the database.
+//
+// Returns:
+//
+//	The PrivilegeCatalog, and an error if the embedded catalog cannot be read.
+func EmbeddedPrivilegeCatalog(version Version) (PrivilegeCatalog, error) {
+	releases := catalogReleases()
+	if len(releases) == 0 {
+		return PrivilegeCatalog{}, fmt.Errorf("no embedded privilege catalog")
+	}
+	release := releases[0]
+	for _, r := range releases {
+		if version.AtLeast(r, 0) {
+			release = r
+		}
+	}
+	return embeddedCatalog(release)
+}
+
+// AllEmbeddedPrivileges returns a catalog holding the privileges of every
+// embedded release, for checks that run before the database version is known.
+//
+// Returns:
+//
+//	The PrivilegeCatalog, and an error if an embedded catalog cannot be read.
+func AllEmbeddedPrivileges() (PrivilegeCatalog, error) {
+	all := newPrivilegeCatalog("embedded catalogs", nil, nil)
+	for _, release := range catalogReleases() {
+		catalog, err := embeddedCatalog(release)
+		if err != nil {
+			return PrivilegeCatalog{}, err
+		}
+		for name := range catalog.system {
+			all.system[name] = true
+		}
+		for name := range catalog.object {
+			all.object[name] = true
+		}
+	}
+	return all, nil
+}
+
+// PrivilegeCatalog returns the catalog of privileges of the connected
+// database. If LivePrivilegeCatalog is set, the names are read from
+// SYSTEM_PRIVILEGE_MAP and TABLE_PRIVILEGE_MAP, otherwise the embedded
+// catalog for the database version is used. The catalog is read once and
+// cached for the lifetime of the client.
+//
+// Returns:
+//
+//	The PrivilegeCatalog, and an error if the catalog cannot be read.
+func (c *Client) PrivilegeCatalog() (PrivilegeCatalog, error) {
+	c.catalogOnce.Do(func() {
+		if c.LivePrivilegeCatalog {
+			c.catalog, c.catalogErr = c.livePrivilegeCatalog()
+			return
+		}
+		version, err := c.DatabaseVersion()
+		if err != nil {
+			c.catalogErr = err
+			return
+		}
+		c.catalog, c.catalogErr = EmbeddedPrivilegeCatalog(version)
+	})
+	return c.catalog, c.catalogErr
+}
+
+// livePrivilegeCatalog reads the privilege names from the data dictionary.
+func (c *Client) livePrivilegeCatalog() (PrivilegeCatalog, error) {
+	system, err := c.queryStrings("SELECT name FROM system_privilege_map")
+	if err != nil {
+		return PrivilegeCatalog{}, fmt.Errorf("error reading system_privilege_map: %w", err)
+	}
+	object, err := c.queryStrings("SELECT name FROM table_privilege_map")
+	if err != nil {
+		return PrivilegeCatalog{}, fmt.Errorf("error reading table_privilege_map: %w", err)
+	}
+	return newPrivilegeCatalog("live dictionary", system, object), nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestEmbeddedPrivilegeCatalog(t *testing.T) {
+	catalog, err := oracle.EmbeddedPrivilegeCatalog(oracle.Version{Major: 19, Minor: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, "embedded catalog for Oracle 19", catalog.Source)
+	assert.NoError(t, catalog.Check(oracle.SystemPrivilegeKind, "create session"))
+	assert.NoError(t, catalog.Check(oracle.ObjectPrivilegeKind, "SELECT"))
+	assert.Error(t, catalog.Check(oracle.SystemPrivilegeKind, "CREATE DOMAIN"))
+	assert.Equal(t, []string{"EXECUTE", "READ", "WRITE"}, catalog.Names(oracle.DirectoryPrivilegeKind))
+
+	catalog, err = oracle.EmbeddedPrivilegeCatalog(oracle.Version{Major: 23, Minor: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, "embedded catalog for Oracle 23", catalog.Source)
+	assert.NoError(t, catalog.Check(oracle.SystemPrivilegeKind, "CREATE DOMAIN"))
+
+	// Older releases use the oldest catalog.
+	catalog, err = oracle.EmbeddedPrivilegeCatalog(oracle.Version{Major: 12, Minor: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "embedded catalog for Oracle 19", catalog.Source)
+}
+
+func TestPrivilegeCatalog_Check(t *testing.T) {
+	catalog, err := oracle.AllEmbeddedPrivileges()
+	assert.NoError(t, err)
+
+	assert.EqualError(t, catalog.Check(oracle.SystemPrivilegeKind, "CREAT SESSION"), `"CREAT SESSION" is not a known system privilege, did you mean "CREATE SESSION"?`)
+	assert.EqualError(t, catalog.Check(oracle.SystemPrivilegeKind, "select any tabel"), `"SELECT ANY TABEL" is not a known system privilege, did you mean "SELECT ANY TABLE"?`)
+	assert.EqualError(t, catalog.Check(oracle.ObjectPrivilegeKind, "SELCT"), `"SELCT" is not a known object privilege, did you mean "SELECT"?`)
+	assert.EqualError(t, catalog.Check(oracle.DirectoryPrivilegeKind, "SELECT"), `"SELECT" is not a known directory privilege`)
+	assert.EqualError(t, catalog.Check(oracle.SystemPrivilegeKind, "MAKE COFFEE"), `"MAKE COFFEE" is not a known system privilege`)
+	assert.NoError(t, catalog.Check(oracle.DirectoryPrivilegeKind, "read"))
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestParsePrivilege(t *testing.T) {
+	assert.Equal(t, oracle.Privilege{Name: "CREATE SESSION"}, oracle.ParsePrivilege("create session"))
+	assert.Equal(t, oracle.Privilege{Name: "CREATE SESSION", AdminOption: true}, oracle.ParsePrivilege("CREATE SESSION WITH ADMIN OPTION"))
+	assert.Equal(t, oracle.Privilege{Name: "SELECT", GrantOption: true}, oracle.ParsePrivilege("SELECT WITH GRANT OPTION"))
+	assert.Equal(t, oracle.Privilege{Name: "SELECT", GrantOption: true, HierarchyOption: true}, oracle.ParsePrivilege(" SELECT WITH HIERARCHY OPTION WITH GRANT OPTION "))
+
+	for _, privilege := range []string{"READ", "SELECT WITH GRANT OPTION", "SELECT WITH HIERARCHY OPTION WITH GRANT OPTION", "DROP ANY TABLE WITH ADMIN OPTION"} {
+		assert.Equal(t, privilege, oracle.ParsePrivilege(privilege).String())
+	}
+}
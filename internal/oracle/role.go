@@ -3,11 +3,38 @@
 
 package oracle
 
-import "fmt"
+import (
+	"database/sql"
+	"fmt"
+)
 
 // Role represents an Oracle database role.
 type Role struct {
-	Name string // The name of the role.
+	Name               string // The name of the role.
+	ContainerScope     string // The container scope, either "current" or "all" for common roles in a CDB.
+	AuthenticationType string // The authentication type: "none", "password", "application", "external" or "global".
+	Password           string // The password of the role. Only used for authentication type "password"; never read back.
+	Package            string // The package enabling a secure application role, as "schema.package". Only used for authentication type "application".
+	OracleMaintained   bool   // Whether the role was created by Oracle scripts. Read-only.
+	Common             bool   // Whether the role is a common role in a CDB. Read-only.
+	Inherited          bool   // Whether the role definition was inherited from another container. Read-only.
+	Implicit           bool   // Whether the role is an implicitly created common role. Read-only.
+}
+
+// RolePrivileges holds the privileges granted directly to a role and the
+// users and roles the role is granted to.
+type RolePrivileges struct {
+	SystemPrivileges []string              // The system privileges granted to the role.
+	ObjectPrivileges []RoleObjectPrivilege // The object privileges granted to the role.
+	Roles            []string              // The roles granted to the role.
+	Grantees         []string              // The users and roles the role is granted to.
+}
+
+// RoleObjectPrivilege represents a single object privilege granted to a role.
+type RoleObjectPrivilege struct {
+	Owner     string // The owner of the object.
+	Object    string // The name of the object.
+	Privilege string // The privilege granted on the object.
 }
 
 // CreateRole creates a new role in the Oracle database.
@@ -21,10 +48,38 @@ type Role struct {
 //	An error if the role creation fails.
 func (c *Client) CreateRole(role Role) error {
 	sql := fmt.Sprintf("CREATE ROLE %s", role.Name)
+	if role.AuthenticationType != "" && role.AuthenticationType != "none" {
+		clause, err := roleIdentifiedClause(role)
+		if err != nil {
+			return err
+		}
+		sql += clause
+	}
+	sql += containerClause(role.ContainerScope)
 	_, err := c.DB.Exec(sql)
 	return err
 }
 
+// ModifyRole changes the authentication of an existing role.
+//
+// Parameters:
+//
+//	role: A Role struct containing the new authentication details of the role.
+//
+// Returns:
+//
+//	An error if the role modification fails.
+func (c *Client) ModifyRole(role Role) error {
+	clause, err := roleIdentifiedClause(role)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER ROLE %s", role.Name) + clause
+	sql += containerClause(role.ContainerScope)
+	_, err = c.DB.Exec(sql)
+	return err
+}
+
 // DropRole drops a role from the Oracle database.
 //
 // Parameters:
@@ -70,10 +125,113 @@ func (c *Client) RoleExists(roleName string) (bool, error) {
 //	A Role struct containing the role's details and an error if the read fails.
 func (c *Client) ReadRole(roleName string) (*Role, error) {
 	role := &Role{}
-	sql := "SELECT role FROM dba_roles WHERE role = UPPER(:1)"
-	err := c.DB.QueryRow(sql, roleName).Scan(&role.Name)
+	var common, authType, oracleMaintained string
+	var inherited, implicit, schema, pkg sql.NullString
+
+	version, err := c.DatabaseVersion()
+	if err != nil {
+		return nil, err
+	}
+	// dba_roles.inherited and dba_roles.implicit were added in Oracle 12.2
+	inheritedColumns := "NULL, NULL"
+	if version.AtLeast(12, 2) {
+		inheritedColumns = "r.inherited, r.implicit"
+	}
+
+	query := "SELECT r.role, r.common, r.authentication_type, r.oracle_maintained, " + inheritedColumns + `, a.schema, a.package
+		FROM dba_roles r
+		LEFT JOIN dba_application_roles a ON a.role = r.role
+		WHERE r.role = UPPER(:1)`
+	err = c.DB.QueryRow(query, roleName).Scan(&role.Name, &common, &authType, &oracleMaintained, &inherited, &implicit, &schema, &pkg)
 	if err != nil {
 		return nil, err
 	}
+	role.ContainerScope = scopeFromCommon(common)
+	role.AuthenticationType = authenticationType(authType)
+	role.OracleMaintained = oracleMaintained == "Y"
+	role.Common = common == "YES"
+	role.Inherited = inherited.String == "YES"
+	role.Implicit = implicit.String == "YES"
+	if pkg.Valid {
+		role.Package = schema.String + "." + pkg.String
+	}
 	return role, nil
 }
+
+// ReadRolePrivileges reads the privileges granted directly to a role and the
+// users and roles the role is granted to.
+//
+// Parameters:
+//
+//	roleName: The name of the role to read.
+//
+// Returns:
+//
+//	A RolePrivileges struct containing the role's privileges and grantees, and an error if the read fails.
+func (c *Client) ReadRolePrivileges(roleName string) (*RolePrivileges, error) {
+	privileges := &RolePrivileges{}
+
+	var err error
+	privileges.SystemPrivileges, err = c.queryStrings("SELECT DISTINCT privilege FROM dba_sys_privs WHERE grantee = UPPER(:1) ORDER BY privilege", roleName)
+	if err != nil {
+		return nil, err
+	}
+	privileges.Roles, err = c.queryStrings("SELECT DISTINCT granted_role FROM dba_role_privs WHERE grantee = UPPER(:1) ORDER BY granted_role", roleName)
+	if err != nil {
+		return nil, err
+	}
+	privileges.Grantees, err = c.queryStrings("SELECT DISTINCT grantee FROM dba_role_privs WHERE granted_role = UPPER(:1) ORDER BY grantee", roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.DB.Query("SELECT DISTINCT owner, table_name, privilege FROM dba_tab_privs WHERE grantee = UPPER(:1) ORDER BY owner, table_name, privilege", roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var privilege RoleObjectPrivilege
+		if err := rows.Scan(&privilege.Owner, &privilege.Object, &privilege.Privilege); err != nil {
+			return nil, err
+		}
+		privileges.ObjectPrivileges = append(privileges.ObjectPrivileges, privilege)
+	}
+	return privileges, rows.Err()
+}
+
+// queryStrings runs a query returning a single string column and collects the results.
+func (c *Client) queryStrings(query string, args ...any) ([]string, error) {
+	rows, err := c.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// roleIdentifiedClause returns the IDENTIFIED clause for the authentication
+// type of a role, or an error if the password cannot be used.
+func roleIdentifiedClause(role Role) (string, error) {
+	switch role.AuthenticationType {
+	case "password":
+		return identifiedByPassword(role.Password)
+	case "application":
+		return fmt.Sprintf(" IDENTIFIED USING %s", role.Package), nil
+	case "external":
+		return " IDENTIFIED EXTERNALLY", nil
+	case "global":
+		return " IDENTIFIED GLOBALLY", nil
+	}
+	return " NOT IDENTIFIED", nil
+}
@@ -44,5 +44,38 @@ func TestRole(t *testing.T) {
 
 	assert.NoError(t, client.CreateRole(testRole))
 
+	role, err := client.ReadRole(testRole.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "none", role.AuthenticationType)
+	assert.False(t, role.OracleMaintained)
+	assert.False(t, role.Common)
+
+	assert.NoError(t, client.GrantSystemPrivileges(oracle.Grant{Principal: testRole.Name, Privileges: []oracle.Privilege{{Name: "CREATE SESSION"}}, GrantsMode: "append"}))
+
+	privileges, err := client.ReadRolePrivileges(testRole.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CREATE SESSION"}, privileges.SystemPrivileges)
+	assert.Empty(t, privileges.ObjectPrivileges)
+	assert.Empty(t, privileges.Roles)
+
+	// A double quote would end the quoted password
+	testRole.AuthenticationType = "password"
+	testRole.Password = `pass" IDENTIFIED EXTERNALLY --`
+	assert.Error(t, client.ModifyRole(testRole))
+
+	testRole.Password = "password"
+	assert.NoError(t, client.ModifyRole(testRole))
+
+	role, err = client.ReadRole(testRole.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", role.AuthenticationType)
+
+	testRole.AuthenticationType = "none"
+	assert.NoError(t, client.ModifyRole(testRole))
+
+	role, err = client.ReadRole(testRole.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "none", role.AuthenticationType)
+
 	assert.NoError(t, client.DropRole(testRole.Name))
 }
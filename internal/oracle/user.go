@@ -4,7 +4,13 @@
 package oracle
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sijms/go-ora/v2/network"
 )
 
 // User represents an Oracle database user.
@@ -12,11 +18,31 @@ import (
 type User struct {
 	Username              string // The name of the user.
 	Password              string // The user's password. Only used for authentication type "password".
+	PasswordHash          string // An existing password verifier used with IDENTIFIED BY VALUES instead of Password.
 	DefaultTablespace     string // The default tablespace for the user.
 	DefaultTempTablespace string // The default temporary tablespace for the user.
 	Profile               string // The user's profile.
-	AuthenticationType    string // The authentication type, e.g., "password", "external", "global".
-	State                 string // The desired state of the user account, e.g., "locked", "unlocked".
+	AuthenticationType    string // The authentication type, e.g., "password", "external", "global", "none".
+	State                 string // The desired state of the user account, e.g., "locked", "unlocked". ReadUser derives it from the account status.
+	ExternalName          string // The external (e.g., Kerberos principal) or global (directory DN) name for "external" and "global" users.
+	ContainerScope        string // The container scope, either "current" or "all" for common users in a CDB.
+	LocalTempTablespace   string // The local temporary tablespace for the user (Oracle 18c and later).
+	EditionsEnabled       bool   // Whether editions are enabled for the user. ModifyUser only enables editions; they cannot be disabled.
+
+	RawAccountStatus string    // The raw dba_users.account_status value. Only populated by ReadUser.
+	Expired          bool      // Whether the password has expired (grace periods excluded). Only populated by ReadUser.
+	LockDate         time.Time // The date the account was locked, or the zero time. Only populated by ReadUser.
+	ExpiryDate       time.Time // The date the password expires, or the zero time. Only populated by ReadUser.
+}
+
+// AccountStatus is the parsed form of a dba_users.account_status value such as
+// "EXPIRED(GRACE) & LOCKED(TIMED)".
+type AccountStatus struct {
+	Locked      bool // The account was locked explicitly with ACCOUNT LOCK.
+	TimedLock   bool // The account is temporarily locked after too many failed logins.
+	Expired     bool // The password has expired and must be changed at the next login.
+	GracePeriod bool // The password is in its grace period and will expire soon.
+	InRollover  bool // The password is in a gradual password rollover period.
 }
 
 // CreateUser creates a new user in the Oracle database.
@@ -31,13 +57,22 @@ type User struct {
 func (c *Client) CreateUser(user User) error {
 	sql := fmt.Sprintf("CREATE USER %s", user.Username)
 
-	switch user.AuthenticationType {
-	case "password":
-		sql += fmt.Sprintf(" IDENTIFIED BY \"%s\"", user.Password)
-	case "external":
-		sql += " IDENTIFIED EXTERNALLY"
-	case "global":
-		sql += " IDENTIFIED GLOBALLY"
+	switch {
+	case user.AuthenticationType == "password" && user.PasswordHash != "":
+		if err := ValidatePasswordVerifier(user.PasswordHash); err != nil {
+			return err
+		}
+		sql += fmt.Sprintf(" IDENTIFIED BY VALUES '%s'", user.PasswordHash)
+	case user.AuthenticationType == "password":
+		clause, err := identifiedByPassword(user.Password)
+		if err != nil {
+			return err
+		}
+		sql += clause
+	case user.AuthenticationType == "external", user.AuthenticationType == "global":
+		sql += identifiedClause(user)
+	case user.AuthenticationType == "none":
+		sql += " NO AUTHENTICATION"
 	}
 
 	if user.DefaultTablespace != "" {
@@ -48,6 +83,10 @@ func (c *Client) CreateUser(user User) error {
 		sql += fmt.Sprintf(" TEMPORARY TABLESPACE %s", user.DefaultTempTablespace)
 	}
 
+	if user.LocalTempTablespace != "" {
+		sql += fmt.Sprintf(" LOCAL TEMPORARY TABLESPACE %s", user.LocalTempTablespace)
+	}
+
 	if user.Profile != "" {
 		sql += fmt.Sprintf(" PROFILE %s", user.Profile)
 	}
@@ -56,6 +95,12 @@ func (c *Client) CreateUser(user User) error {
 		sql += " ACCOUNT LOCK"
 	}
 
+	if user.EditionsEnabled {
+		sql += " ENABLE EDITIONS"
+	}
+
+	sql += containerClause(user.ContainerScope)
+
 	_, err := c.DB.Exec(sql)
 	return err
 }
@@ -72,8 +117,22 @@ func (c *Client) CreateUser(user User) error {
 func (c *Client) ModifyUser(user User) error {
 	sql := fmt.Sprintf("ALTER USER %s", user.Username)
 
-	if user.Password != "" {
-		sql += fmt.Sprintf(" IDENTIFIED BY \"%s\"", user.Password)
+	switch {
+	case user.AuthenticationType == "none":
+		sql += " NO AUTHENTICATION"
+	case user.AuthenticationType == "external", user.AuthenticationType == "global":
+		sql += identifiedClause(user)
+	case user.PasswordHash != "":
+		if err := ValidatePasswordVerifier(user.PasswordHash); err != nil {
+			return err
+		}
+		sql += fmt.Sprintf(" IDENTIFIED BY VALUES '%s'", user.PasswordHash)
+	case user.Password != "":
+		clause, err := identifiedByPassword(user.Password)
+		if err != nil {
+			return err
+		}
+		sql += clause
 	}
 
 	if user.DefaultTablespace != "" {
@@ -84,10 +143,18 @@ func (c *Client) ModifyUser(user User) error {
 		sql += fmt.Sprintf(" TEMPORARY TABLESPACE %s", user.DefaultTempTablespace)
 	}
 
+	if user.LocalTempTablespace != "" {
+		sql += fmt.Sprintf(" LOCAL TEMPORARY TABLESPACE %s", user.LocalTempTablespace)
+	}
+
 	if user.Profile != "" {
 		sql += fmt.Sprintf(" PROFILE %s", user.Profile)
 	}
 
+	if user.EditionsEnabled {
+		sql += " ENABLE EDITIONS"
+	}
+
 	switch user.State {
 	case "locked":
 		sql += " ACCOUNT LOCK"
@@ -95,25 +162,95 @@ func (c *Client) ModifyUser(user User) error {
 		sql += " ACCOUNT UNLOCK"
 	}
 
+	// Nothing to change
+	if sql == fmt.Sprintf("ALTER USER %s", user.Username) {
+		return nil
+	}
+
+	sql += containerClause(user.ContainerScope)
+
 	_, err := c.DB.Exec(sql)
 	return err
 }
 
 // DropUser drops a user from the Oracle database.
+// Without cascade, the database refuses the drop if the user still owns objects.
 //
 // Parameters:
 //
 //	username: The name of the user to be dropped.
+//	cascade: Whether to drop the objects owned by the user as well.
 //
 // Returns:
 //
-//	An error if the user drop fails.
-func (c *Client) DropUser(username string) error {
-	sql := fmt.Sprintf("DROP USER %s CASCADE", username)
+//	An error if the user owns objects and cascade is false, or if the user drop fails.
+func (c *Client) DropUser(username string, cascade bool) error {
+	sql := fmt.Sprintf("DROP USER %s", username)
+	if cascade {
+		sql += " CASCADE"
+	}
 	_, err := c.DB.Exec(sql)
+	// ORA-01922: CASCADE must be specified to drop 'USER'
+	var oraErr *network.OracleError
+	if !cascade && errors.As(err, &oraErr) && oraErr.ErrCode == 1922 {
+		return fmt.Errorf("user %s owns objects and cannot be dropped without CASCADE", username)
+	}
 	return err
 }
 
+// LockAndExpireUser locks a user account and expires its password
+// instead of dropping the user.
+//
+// Parameters:
+//
+//	username: The name of the user to lock.
+//
+// Returns:
+//
+//	An error if the user modification fails.
+func (c *Client) LockAndExpireUser(username string) error {
+	sql := fmt.Sprintf("ALTER USER %s PASSWORD EXPIRE ACCOUNT LOCK", username)
+	_, err := c.DB.Exec(sql)
+	return err
+}
+
+// KillUserSessions terminates all sessions of a user listed in v$session.
+//
+// Parameters:
+//
+//	username: The name of the user whose sessions should be terminated.
+//
+// Returns:
+//
+//	An error if a session cannot be terminated.
+func (c *Client) KillUserSessions(username string) error {
+	rows, err := c.DB.Query("SELECT sid, serial# FROM v$session WHERE username = UPPER(:1)", username)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var sessions []string
+	for rows.Next() {
+		var sid, serial int
+		if err := rows.Scan(&sid, &serial); err != nil {
+			return err
+		}
+		sessions = append(sessions, fmt.Sprintf("%d,%d", sid, serial))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		sql := fmt.Sprintf("ALTER SYSTEM KILL SESSION '%s' IMMEDIATE", session)
+		if _, err := c.DB.Exec(sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UserExists checks if a user exists in the database.
 //
 // Parameters:
@@ -144,10 +281,136 @@ func (c *Client) UserExists(username string) (bool, error) {
 //	A User struct containing the user's details, and an error if the read fails.
 func (c *Client) ReadUser(username string) (*User, error) {
 	user := &User{}
-	sql := "SELECT username, default_tablespace, temporary_tablespace, profile, authentication_type, account_status FROM dba_users WHERE username = UPPER(:1)"
-	err := c.DB.QueryRow(sql, username).Scan(&user.Username, &user.DefaultTablespace, &user.DefaultTempTablespace, &user.Profile, &user.AuthenticationType, &user.State)
+	var externalName sql.NullString
+	var lockDate, expiryDate sql.NullTime
+	var common, editionsEnabled string
+	var localTempTablespace sql.NullString
+
+	version, err := c.DatabaseVersion()
+	if err != nil {
+		return nil, err
+	}
+	// dba_users.local_temp_tablespace exists since Oracle 12.2, but the LOCAL
+	// TEMPORARY TABLESPACE clause requires Oracle 18c, so the column is only
+	// read where the provider can manage it
+	localTempColumn := "NULL"
+	if version.AtLeast(18, 0) {
+		localTempColumn = "local_temp_tablespace"
+	}
+
+	query := "SELECT username, default_tablespace, temporary_tablespace, profile, authentication_type, account_status, external_name, lock_date, expiry_date, common, editions_enabled, " +
+		localTempColumn + " FROM dba_users WHERE username = UPPER(:1)"
+	err = c.DB.QueryRow(query, username).Scan(&user.Username, &user.DefaultTablespace, &user.DefaultTempTablespace, &user.Profile, &user.AuthenticationType, &user.RawAccountStatus, &externalName, &lockDate, &expiryDate, &common, &editionsEnabled, &localTempTablespace)
 	if err != nil {
 		return nil, err
 	}
+
+	status := ParseAccountStatus(user.RawAccountStatus)
+
+	user.AuthenticationType = authenticationType(user.AuthenticationType)
+	user.ExternalName = externalName.String
+	user.ContainerScope = scopeFromCommon(common)
+	user.LocalTempTablespace = localTempTablespace.String
+	user.EditionsEnabled = editionsEnabled == "Y"
+	user.State = "unlocked"
+	if status.Locked {
+		user.State = "locked"
+	}
+	user.Expired = status.Expired
+	user.LockDate = lockDate.Time
+	user.ExpiryDate = expiryDate.Time
 	return user, nil
 }
+
+// ParseAccountStatus parses a dba_users.account_status value.
+//
+// Oracle combines the individual states with " & ", for example
+// "EXPIRED & LOCKED(TIMED)" or "OPEN & IN ROLLOVER". A timed lock caused by
+// failed login attempts is reported separately from an explicit ACCOUNT LOCK,
+// and a password in its grace period is not considered expired. States that
+// are not known, for example from a newer release, are derived from the words
+// LOCKED, EXPIRED and ROLLOVER they contain.
+//
+// Parameters:
+//
+//	status: The account status as reported by dba_users.
+//
+// Returns:
+//
+//	The parsed AccountStatus.
+func ParseAccountStatus(status string) AccountStatus {
+	var result AccountStatus
+	for _, part := range strings.Split(status, "&") {
+		state := strings.ToUpper(strings.TrimSpace(part))
+		switch state {
+		case "OPEN":
+		case "LOCKED":
+			result.Locked = true
+		case "LOCKED(TIMED)":
+			result.TimedLock = true
+		case "EXPIRED":
+			result.Expired = true
+		case "EXPIRED(GRACE)":
+			result.GracePeriod = true
+		case "IN ROLLOVER":
+			result.InRollover = true
+		default:
+			switch {
+			case strings.Contains(state, "LOCKED(TIMED)"):
+				result.TimedLock = true
+			case strings.Contains(state, "LOCKED"):
+				result.Locked = true
+			}
+			switch {
+			case strings.Contains(state, "EXPIRED(GRACE)"):
+				result.GracePeriod = true
+			case strings.Contains(state, "EXPIRED"):
+				result.Expired = true
+			}
+			if strings.Contains(state, "ROLLOVER") {
+				result.InRollover = true
+			}
+		}
+	}
+	return result
+}
+
+// identifiedByPassword returns the IDENTIFIED BY clause of a password. The
+// password is written as a quoted identifier, which cannot contain a double
+// quote, so such passwords are rejected instead of breaking the statement.
+func identifiedByPassword(password string) (string, error) {
+	if strings.Contains(password, `"`) {
+		return "", fmt.Errorf("password must not contain double quotes")
+	}
+	return fmt.Sprintf(" IDENTIFIED BY \"%s\"", password), nil
+}
+
+// identifiedClause returns the IDENTIFIED EXTERNALLY or IDENTIFIED GLOBALLY
+// clause for a user, including the AS clause when an external name is set.
+func identifiedClause(user User) string {
+	clause := " IDENTIFIED EXTERNALLY"
+	if user.AuthenticationType == "global" {
+		clause = " IDENTIFIED GLOBALLY"
+	}
+	if user.ExternalName != "" {
+		clause += fmt.Sprintf(" AS '%s'", strings.ReplaceAll(user.ExternalName, "'", "''"))
+	}
+	return clause
+}
+
+// authenticationType maps a dba_users.authentication_type value to the
+// lowercase form accepted by CreateUser and ModifyUser. Schema-only accounts
+// created with NO AUTHENTICATION are reported by Oracle as NONE.
+func authenticationType(value string) string {
+	switch strings.ToUpper(value) {
+	case "PASSWORD":
+		return "password"
+	case "EXTERNAL":
+		return "external"
+	case "GLOBAL":
+		return "global"
+	case "NONE":
+		return "none"
+	}
+	return strings.ToLower(value)
+}
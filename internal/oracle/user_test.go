@@ -41,7 +41,7 @@ func TestUser(t *testing.T) {
 	exists, err := client.UserExists(testUser.Username)
 	assert.NoError(t, err)
 	if exists {
-		assert.NoError(t, client.DropUser(testUser.Username))
+		assert.NoError(t, client.DropUser(testUser.Username, true))
 	}
 
 	assert.NoError(t, client.CreateUser(testUser))
@@ -52,5 +52,59 @@ func TestUser(t *testing.T) {
 	}
 	assert.NoError(t, client.ModifyUser(modifiedUser))
 
-	assert.NoError(t, client.DropUser(testUser.Username))
+	// A double quote would end the quoted password
+	quotedUser := testUser
+	quotedUser.Username = "testuser_quoted"
+	quotedUser.Password = `pass" IDENTIFIED EXTERNALLY --`
+	assert.Error(t, client.CreateUser(quotedUser))
+	exists, err = client.UserExists(quotedUser.Username)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	modifiedUser.Password = `pass" ACCOUNT UNLOCK --`
+	assert.Error(t, client.ModifyUser(modifiedUser))
+
+	// Without cascade, a user that owns objects is not dropped
+	_, err = client.ExecuteSQL("CREATE SEQUENCE testuser.test_drop_seq")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, client.DropUser(testUser.Username, false), "cannot be dropped without CASCADE")
+	exists, err = client.UserExists(testUser.Username)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, client.DropUser(testUser.Username, true))
+}
+
+func TestParseAccountStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected oracle.AccountStatus
+	}{
+		{"OPEN", oracle.AccountStatus{}},
+		{"EXPIRED", oracle.AccountStatus{Expired: true}},
+		{"EXPIRED(GRACE)", oracle.AccountStatus{GracePeriod: true}},
+		{"LOCKED(TIMED)", oracle.AccountStatus{TimedLock: true}},
+		{"LOCKED", oracle.AccountStatus{Locked: true}},
+		{"EXPIRED & LOCKED(TIMED)", oracle.AccountStatus{Expired: true, TimedLock: true}},
+		{"EXPIRED(GRACE) & LOCKED(TIMED)", oracle.AccountStatus{GracePeriod: true, TimedLock: true}},
+		{"EXPIRED & LOCKED", oracle.AccountStatus{Expired: true, Locked: true}},
+		{"EXPIRED(GRACE) & LOCKED", oracle.AccountStatus{GracePeriod: true, Locked: true}},
+		{"OPEN & IN ROLLOVER", oracle.AccountStatus{InRollover: true}},
+		{"EXPIRED & IN ROLLOVER", oracle.AccountStatus{Expired: true, InRollover: true}},
+		{"LOCKED & IN ROLLOVER", oracle.AccountStatus{Locked: true, InRollover: true}},
+		{"EXPIRED & LOCKED & IN ROLLOVER", oracle.AccountStatus{Expired: true, Locked: true, InRollover: true}},
+		{"LOCKED(TIMED) & IN ROLLOVER", oracle.AccountStatus{TimedLock: true, InRollover: true}},
+		{"EXPIRED & LOCKED(TIMED) & IN ROLLOVER", oracle.AccountStatus{Expired: true, TimedLock: true, InRollover: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.expected, oracle.ParseAccountStatus(tt.status))
+		})
+	}
+
+	// Unknown states are derived from their wording instead of failing
+	assert.Equal(t, oracle.AccountStatus{Expired: true}, oracle.ParseAccountStatus("EXPIRED & UNKNOWN"))
+	assert.Equal(t, oracle.AccountStatus{Locked: true, GracePeriod: true}, oracle.ParseAccountStatus("LOCKED(ADMIN) & EXPIRED(GRACE)"))
+	assert.Equal(t, oracle.AccountStatus{TimedLock: true}, oracle.ParseAccountStatus("LOCKED(TIMED) & NEW STATE"))
 }
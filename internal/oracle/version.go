@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version represents the release of an Oracle database, e.g. 19.0 or 23.4.
+type Version struct {
+	Major int // The major release, e.g. 19.
+	Minor int // The minor release or release update, e.g. 0.
+}
+
+// AtLeast checks if the version is the same as or newer than the given release.
+func (v Version) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// String returns the version in "major.minor" form.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// ParseVersion parses a version string such as "19.0.0.0.0" or "23.4.0.24.05".
+//
+// Parameters:
+//
+//	version: The version string as reported by product_component_version.
+//
+// Returns:
+//
+//	The parsed Version, and an error if the version string is malformed.
+func ParseVersion(version string) (Version, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) < 2 {
+		return Version{}, fmt.Errorf("invalid database version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid database version %q: %w", version, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid database version %q: %w", version, err)
+	}
+	return Version{Major: major, Minor: minor}, nil
+}
+
+// DatabaseVersion returns the release of the connected database.
+// The version is read once and cached for the lifetime of the client.
+//
+// Returns:
+//
+//	The database Version, and an error if the version cannot be read.
+func (c *Client) DatabaseVersion() (Version, error) {
+	c.versionOnce.Do(func() {
+		var version string
+		sql := "SELECT version FROM product_component_version WHERE product LIKE 'Oracle Database%' AND ROWNUM = 1"
+		if c.versionErr = c.DB.QueryRow(sql).Scan(&version); c.versionErr != nil {
+			return
+		}
+		c.version, c.versionErr = ParseVersion(version)
+	})
+	return c.version, c.versionErr
+}
+
+// RequireVersion checks that the connected database is at least the given release.
+//
+// Parameters:
+//
+//	major: The minimum major release.
+//	minor: The minimum minor release.
+//	feature: A description of the feature that requires the release, used in the error message.
+//
+// Returns:
+//
+//	An error if the database is older than the given release or its version cannot be read.
+func (c *Client) RequireVersion(major, minor int, feature string) error {
+	version, err := c.DatabaseVersion()
+	if err != nil {
+		return err
+	}
+	if !version.AtLeast(major, minor) {
+		return fmt.Errorf("%s require Oracle %d.%d or later, the database version is %s", feature, major, minor, version)
+	}
+	return nil
+}
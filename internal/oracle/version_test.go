@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oracle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestParseVersion(t *testing.T) {
+	version, err := oracle.ParseVersion("19.0.0.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, oracle.Version{Major: 19, Minor: 0}, version)
+	assert.True(t, version.AtLeast(18, 0))
+	assert.True(t, version.AtLeast(19, 0))
+	assert.False(t, version.AtLeast(23, 0))
+
+	version, err = oracle.ParseVersion("12.2.0.1.0")
+	assert.NoError(t, err)
+	assert.True(t, version.AtLeast(12, 2))
+	assert.False(t, version.AtLeast(18, 0))
+	assert.Equal(t, "12.2", version.String())
+
+	_, err = oracle.ParseVersion("unknown")
+	assert.Error(t, err)
+}
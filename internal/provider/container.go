@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// containerScopeAttribute returns the schema of the container_scope attribute
+// shared by resources that can be created in all containers of a CDB.
+func containerScopeAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description + " Possible values are `current` and `all`. " +
+			"Use `all` in the CDB root to apply the change to all containers (`CONTAINER=ALL`). If not specified, the default is `current`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString("current"),
+		Validators: []validator.String{
+			stringvalidator.OneOf("current", "all"),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// containerScopeValue returns the container scope stored in state, defaulting
+// to "current" for imported resources.
+func containerScopeValue(containerScope types.String) types.String {
+	if containerScope.IsNull() || containerScope.IsUnknown() {
+		return types.StringValue("current")
+	}
+	return containerScope
+}
+
+// importedContainerScope returns the container scope of an imported
+// identifier, defaulting to "current" for identifiers of older versions of the
+// provider that do not include it.
+func importedContainerScope(part string) string {
+	if part == "" {
+		return "current"
+	}
+	return part
+}
@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -49,8 +48,11 @@ func (r *DirectoryResource) Schema(ctx context.Context, req resource.SchemaReque
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the directory object.",
+				MarkdownDescription: "The name of the directory object. Changing this forces a new directory object to be created.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
 			},
 			"path": schema.StringAttribute{
 				MarkdownDescription: "The path to the directory on the database server file system.",
@@ -131,7 +133,7 @@ func (r *DirectoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	data.Name = types.StringValue(strings.ToLower(directory.Name))
+	data.Name = identifierValue(data.Name, directory.Name)
 	data.Path = types.StringValue(directory.Path)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -178,5 +180,10 @@ func (r *DirectoryResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *DirectoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := splitResourceID(req.ID, "name"); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -34,6 +34,11 @@ resource "oracle_directory" "test_dir" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:    "oracle_directory.test_dir",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
 			{
 				Config: providerConfig + fmt.Sprintf(`
 resource "oracle_directory" "test_dir" {
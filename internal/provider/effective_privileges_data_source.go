@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EffectivePrivilegesDataSource{}
+
+func NewEffectivePrivilegesDataSource() datasource.DataSource {
+	return &EffectivePrivilegesDataSource{}
+}
+
+// EffectivePrivilegesDataSource defines the data source implementation.
+type EffectivePrivilegesDataSource struct {
+	client *oracle.Client
+}
+
+// EffectivePrivilegesDataSourceModel describes the data source data model.
+type EffectivePrivilegesDataSourceModel struct {
+	Principal        types.String `tfsdk:"principal"`
+	IncludePublic    types.Bool   `tfsdk:"include_public"`
+	SystemPrivileges types.Set    `tfsdk:"system_privileges"`
+	ObjectPrivileges types.Set    `tfsdk:"object_privileges"`
+	Roles            types.Set    `tfsdk:"roles"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// EffectiveSystemPrivilegeModel describes a system privilege and its grant path.
+type EffectiveSystemPrivilegeModel struct {
+	Privilege   types.String `tfsdk:"privilege"`
+	AdminOption types.Bool   `tfsdk:"admin_option"`
+	Path        types.List   `tfsdk:"path"`
+}
+
+// EffectiveObjectPrivilegeModel describes an object privilege and its grant path.
+type EffectiveObjectPrivilegeModel struct {
+	Owner       types.String `tfsdk:"owner"`
+	Object      types.String `tfsdk:"object"`
+	Privilege   types.String `tfsdk:"privilege"`
+	Columns     types.List   `tfsdk:"columns"`
+	GrantOption types.Bool   `tfsdk:"grant_option"`
+	Path        types.List   `tfsdk:"path"`
+}
+
+// EffectiveRoleModel describes a role and its grant path.
+type EffectiveRoleModel struct {
+	Role        types.String `tfsdk:"role"`
+	AdminOption types.Bool   `tfsdk:"admin_option"`
+	Path        types.List   `tfsdk:"path"`
+}
+
+// grantPathType is the type of the path attributes.
+var grantPathType = types.ListType{ElemType: types.StringType}
+
+// effectiveSystemPrivilegeType is the object type of the system_privileges elements.
+var effectiveSystemPrivilegeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"privilege":    types.StringType,
+		"admin_option": types.BoolType,
+		"path":         grantPathType,
+	},
+}
+
+// effectiveObjectPrivilegeType is the object type of the object_privileges elements.
+var effectiveObjectPrivilegeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"owner":        types.StringType,
+		"object":       types.StringType,
+		"privilege":    types.StringType,
+		"columns":      types.ListType{ElemType: types.StringType},
+		"grant_option": types.BoolType,
+		"path":         grantPathType,
+	},
+}
+
+// effectiveRoleType is the object type of the roles elements.
+var effectiveRoleType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"role":         types.StringType,
+		"admin_option": types.BoolType,
+		"path":         grantPathType,
+	},
+}
+
+func (d *EffectivePrivilegesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_privileges"
+}
+
+func (d *EffectivePrivilegesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	pathAttribute := schema.ListAttribute{
+		MarkdownDescription: "The grant path: the principal followed by the roles through which the privilege is granted, e.g. `[\"APP_USER\", \"ROLE_A\", \"ROLE_B\"]`. The path starts with `PUBLIC` for privileges granted to `PUBLIC`.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads every privilege a user or role can use, including the privileges inherited through nested roles and `PUBLIC`, together with the grant path of each privilege.",
+
+		Attributes: map[string]schema.Attribute{
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "The user or role whose privileges are read.",
+				Required:            true,
+			},
+			"include_public": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the privileges and roles granted to `PUBLIC`. If not specified, the default is `true`.",
+				Optional:            true,
+			},
+			"system_privileges": schema.SetNestedAttribute{
+				MarkdownDescription: "The system privileges the principal can use. A privilege granted through several roles is listed once for each of them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "The name of the privilege.",
+							Computed:            true,
+						},
+						"admin_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege was granted `WITH ADMIN OPTION`.",
+							Computed:            true,
+						},
+						"path": pathAttribute,
+					},
+				},
+			},
+			"object_privileges": schema.SetNestedAttribute{
+				MarkdownDescription: "The object privileges the principal can use. A privilege granted through several roles is listed once for each of them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"owner": schema.StringAttribute{
+							MarkdownDescription: "The owner of the object.",
+							Computed:            true,
+						},
+						"object": schema.StringAttribute{
+							MarkdownDescription: "The name of the object.",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "The privilege granted on the object.",
+							Computed:            true,
+						},
+						"columns": schema.ListAttribute{
+							MarkdownDescription: "The columns a column privilege is granted on, e.g. `UPDATE (EMAIL, PHONE)`. Empty for privileges granted on the whole object.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"grant_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege was granted `WITH GRANT OPTION`.",
+							Computed:            true,
+						},
+						"path": pathAttribute,
+					},
+				},
+			},
+			"roles": schema.SetNestedAttribute{
+				MarkdownDescription: "The roles granted to the principal, directly or through other roles. A role granted through several roles is listed once, with its shortest grant path.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The name of the role.",
+							Computed:            true,
+						},
+						"admin_option": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role was granted `WITH ADMIN OPTION`.",
+							Computed:            true,
+						},
+						"path": pathAttribute,
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Principal identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EffectivePrivilegesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EffectivePrivilegesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EffectivePrivilegesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal := data.Principal.ValueString()
+	if !oracle.IsPublic(principal) {
+		userExists, err := d.client.UserExists(principal)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+			return
+		}
+		roleExists, err := d.client.RoleExists(principal)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+			return
+		}
+		if !userExists && !roleExists {
+			resp.Diagnostics.AddError("Principal Not Found", fmt.Sprintf("The user or role %s does not exist.", principal))
+			return
+		}
+	}
+
+	if data.IncludePublic.IsNull() {
+		data.IncludePublic = types.BoolValue(true)
+	}
+
+	privileges, err := d.client.GetEffectivePrivileges(principal, data.IncludePublic.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read effective privileges, got error: %s", err))
+		return
+	}
+
+	var diags diag.Diagnostics
+	systemPrivileges := make([]EffectiveSystemPrivilegeModel, 0, len(privileges.SystemPrivileges))
+	for _, privilege := range privileges.SystemPrivileges {
+		path, pathDiags := types.ListValueFrom(ctx, types.StringType, privilege.Path)
+		diags.Append(pathDiags...)
+		systemPrivileges = append(systemPrivileges, EffectiveSystemPrivilegeModel{
+			Privilege:   types.StringValue(privilege.Privilege),
+			AdminOption: types.BoolValue(privilege.AdminOption),
+			Path:        path,
+		})
+	}
+	objectPrivileges := make([]EffectiveObjectPrivilegeModel, 0, len(privileges.ObjectPrivileges))
+	for _, privilege := range privileges.ObjectPrivileges {
+		path, pathDiags := types.ListValueFrom(ctx, types.StringType, privilege.Path)
+		diags.Append(pathDiags...)
+		columns, columnDiags := types.ListValueFrom(ctx, types.StringType, append([]string{}, privilege.Columns...))
+		diags.Append(columnDiags...)
+		objectPrivileges = append(objectPrivileges, EffectiveObjectPrivilegeModel{
+			Owner:       types.StringValue(privilege.Owner),
+			Object:      types.StringValue(privilege.Object),
+			Privilege:   types.StringValue(privilege.Privilege),
+			Columns:     columns,
+			GrantOption: types.BoolValue(privilege.GrantOption),
+			Path:        path,
+		})
+	}
+	roles := make([]EffectiveRoleModel, 0, len(privileges.Roles))
+	for _, role := range privileges.Roles {
+		path, pathDiags := types.ListValueFrom(ctx, types.StringType, role.Path)
+		diags.Append(pathDiags...)
+		roles = append(roles, EffectiveRoleModel{
+			Role:        types.StringValue(role.Role),
+			AdminOption: types.BoolValue(role.AdminOption),
+			Path:        path,
+		})
+	}
+	resp.Diagnostics.Append(diags...)
+
+	data.ID = types.StringValue(strings.ToUpper(principal))
+	data.SystemPrivileges, diags = types.SetValueFrom(ctx, effectiveSystemPrivilegeType, systemPrivileges)
+	resp.Diagnostics.Append(diags...)
+	data.ObjectPrivileges, diags = types.SetValueFrom(ctx, effectiveObjectPrivilegeType, objectPrivileges)
+	resp.Diagnostics.Append(diags...)
+	data.Roles, diags = types.SetValueFrom(ctx, effectiveRoleType, roles)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read an effective privileges data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
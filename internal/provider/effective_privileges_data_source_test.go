@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_EffectivePrivilegesDataSource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	user := strings.ToUpper("testuser_" + randString)
+	outer := strings.ToUpper("outer_" + randString)
+	inner := strings.ToUpper("inner_" + randString)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Privileges inherited through nested roles include their grant path
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%[1]s"
+  password = "password"
+}
+
+resource "oracle_role" "outer" {
+  name = "outer_%[1]s"
+}
+
+resource "oracle_role" "inner" {
+  name = "inner_%[1]s"
+}
+
+resource "oracle_grant_system_privileges" "inner" {
+  principal  = oracle_role.inner.name
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+
+resource "oracle_grant_roles" "outer" {
+  principal = oracle_role.outer.name
+  roles     = [oracle_role.inner.name]
+}
+
+resource "oracle_grant_roles" "test_user" {
+  principal = oracle_user.test_user.username
+  roles     = [oracle_role.outer.name]
+}
+
+data "oracle_effective_privileges" "test_user" {
+  principal      = oracle_grant_roles.test_user.principal
+  include_public = false
+
+  depends_on = [oracle_grant_roles.outer, oracle_grant_system_privileges.inner]
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.oracle_effective_privileges.test_user", "id", user),
+					resource.TestCheckResourceAttr("data.oracle_effective_privileges.test_user", "roles.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.oracle_effective_privileges.test_user", "roles.*", map[string]string{
+						"role":   inner,
+						"path.#": "2",
+						"path.0": user,
+						"path.1": outer,
+					}),
+					resource.TestCheckResourceAttr("data.oracle_effective_privileges.test_user", "system_privileges.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.oracle_effective_privileges.test_user", "system_privileges.*", map[string]string{
+						"privilege":    "CREATE SESSION",
+						"admin_option": "false",
+						"path.#":       "3",
+						"path.2":       inner,
+					}),
+					resource.TestCheckResourceAttr("data.oracle_effective_privileges.test_user", "object_privileges.#", "0"),
+				),
+			},
+			// Unknown principals are an error
+			{
+				Config: providerConfig + `
+data "oracle_effective_privileges" "missing" {
+  principal = "no_such_principal"
+}
+`,
+				ExpectError: regexp.MustCompile(`Principal Not Found`),
+			},
+		},
+	})
+}
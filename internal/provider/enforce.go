@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// ignoreAttribute returns the schema of the ignore attribute shared by
+// resources that support enforce mode. The kind describes what is matched,
+// e.g. "privileges" or "roles".
+func ignoreAttribute(kind string) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "Patterns of " + kind + " that `enforce` mode leaves alone, such as grants managed by Oracle or other teams. " +
+			"Patterns are case-insensitive and `*` matches any sequence of characters, for example `UNLIMITED TABLESPACE` or `APEX_*`.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// allowOracleMaintainedAttribute returns the schema of the
+// allow_oracle_maintained attribute shared by resources that support enforce mode.
+func allowOracleMaintainedAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether `enforce` mode may revoke grants of Oracle-maintained users and roles (`oracle_maintained = 'Y'`), such as `SYS` or `DBA`. " +
+			"If not specified, the default is `false` and enforcing the grants of such a principal fails.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// withoutIgnored removes the values matching an ignore pattern from current,
+// unless they are part of the prior state, so that ignored grants do not show
+// up as drift.
+func withoutIgnored(current, prior, ignore []string) []string {
+	values := []string{}
+	for _, value := range current {
+		if oracle.IsIgnored(ignore, value) && !oracle.ContainsFold(prior, value) {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,546 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GrantBulkObjectPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantBulkObjectPrivilegesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantBulkObjectPrivilegesResource{}
+
+func NewGrantBulkObjectPrivilegesResource() resource.Resource {
+	return &GrantBulkObjectPrivilegesResource{}
+}
+
+// GrantBulkObjectPrivilegesResource defines the resource implementation.
+type GrantBulkObjectPrivilegesResource struct {
+	client *oracle.Client
+}
+
+// GrantBulkObjectPrivilegesResourceModel describes the resource data model.
+type GrantBulkObjectPrivilegesResourceModel struct {
+	Principal      types.String `tfsdk:"principal"`
+	Owner          types.String `tfsdk:"owner"`
+	ObjectTypes    types.Set    `tfsdk:"object_types"`
+	IncludePattern types.String `tfsdk:"include_pattern"`
+	ExcludePattern types.String `tfsdk:"exclude_pattern"`
+	Privileges     types.Set    `tfsdk:"privileges"`
+	GrantsMode     types.String `tfsdk:"grants_mode"`
+	ContainerScope types.String `tfsdk:"container_scope"`
+	AllowPublic    types.Bool   `tfsdk:"allow_public"`
+	Objects        types.Set    `tfsdk:"objects"`
+	ToGrant        types.Set    `tfsdk:"to_grant"`
+	ToRevoke       types.Set    `tfsdk:"to_revoke"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant_bulk_object_privileges"
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A resource to grant object privileges on every object of a schema matching a type and name pattern. Objects created or dropped after the last apply are reported as drift, so the next apply grants the privileges on new objects.",
+		Version:             2,
+
+		Attributes: map[string]schema.Attribute{
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "The user or role to whom the privileges are granted. Changing this revokes the grants from the previous principal.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+				Validators: []validator.String{
+					publicPrincipalValidator{},
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "The schema that owns the objects. Changing this revokes the grants on the objects of the previous schema.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+			},
+			"object_types": schema.SetAttribute{
+				MarkdownDescription: "The types of the objects to grant the privileges on. Possible values are `TABLE`, `VIEW`, `MATERIALIZED VIEW`, `SEQUENCE`, `PROCEDURE`, `FUNCTION`, `PACKAGE` and `TYPE`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("TABLE", "VIEW", "MATERIALIZED VIEW", "SEQUENCE", "PROCEDURE", "FUNCTION", "PACKAGE", "TYPE")),
+				},
+			},
+			"include_pattern": schema.StringAttribute{
+				MarkdownDescription: "The pattern the object names must match. Patterns are case-insensitive and `*` matches any sequence of characters, e.g. `APP_*`. If not specified, the default is `*`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("*"),
+			},
+			"exclude_pattern": schema.StringAttribute{
+				MarkdownDescription: "The pattern of object names to leave out, e.g. `*_STAGING`. Uses the same syntax as `include_pattern`.",
+				Optional:            true,
+			},
+			"privileges": objectPrivilegeOptions.attribute("The privileges to grant on each matching object, e.g. `{ privilege = \"SELECT\" }`."),
+			"grants_mode": schema.StringAttribute{
+				MarkdownDescription: "The grants mode to use. In `enforce` mode, other privileges of the principal on the matching objects are revoked. If not specified, the default is `append`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
+			},
+			"container_scope": containerScopeAttribute("The container scope of the grants."),
+			"allow_public":    allowPublicAttribute(),
+			"objects": schema.SetAttribute{
+				MarkdownDescription: "The names of the matching objects on which all privileges are granted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"to_grant":  toGrantAttribute("privileges"),
+			"to_revoke": toRevokeAttribute("privileges"),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Grant identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the grants on the objects that currently match, so that
+// objects created or dropped since the last apply show up as a change, and
+// previews the privileges that are granted and revoked.
+func (r *GrantBulkObjectPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planResourceID(ctx, req, resp, "principal", "owner", "object_types", "include_pattern", "exclude_pattern", "container_scope")
+
+	// The objects are not known before the provider is configured.
+	if r.client == nil {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var plan GrantBulkObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Owner.IsUnknown() || plan.ObjectTypes.IsUnknown() || plan.IncludePattern.IsUnknown() || plan.ExcludePattern.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("objects"), types.SetUnknown(types.StringType))...)
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	objects, diags := r.matchingObjects(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("objects"), objects)...)
+
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var planObjects []string
+	resp.Diagnostics.Append(objects.ElementsAs(ctx, &planObjects, false)...)
+	planPrivileges, diags := objectPrivilegeOptions.privileges(ctx, plan.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := objectPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := oracle.BulkObjectPrivilege{
+		Principal:      plan.Principal.ValueString(),
+		Owner:          plan.Owner.ValueString(),
+		Objects:        planObjects,
+		Privileges:     planPrivileges,
+		GrantsMode:     plan.GrantsMode.ValueString(),
+		ContainerScope: plan.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	// Update also revokes the privileges of the prior state that are no
+	// longer planned.
+	var revokes []oracle.BulkObjectPrivilege
+	if !req.State.Raw.IsNull() {
+		var state GrantBulkObjectPrivilegesResourceModel
+		var stateObjects []string
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Objects.ElementsAs(ctx, &stateObjects, false)...)
+		statePrivileges, diags := objectPrivilegeOptions.privileges(ctx, state.Privileges)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		revokes = []oracle.BulkObjectPrivilege{
+			{Objects: difference(stateObjects, planObjects), Privileges: statePrivileges, Managed: managed},
+			{Objects: planObjects, Privileges: removedPrivileges(statePrivileges, planPrivileges), Managed: managed},
+		}
+	}
+
+	grantPlan, err := r.client.PlanBulkObjectPrivileges(grant, revokes)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read object privileges, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, plan.Principal.ValueString(), &grantPlan)
+}
+
+func (r *GrantBulkObjectPrivilegesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantBulkObjectPrivilegesResourceModel](objectPrivilegeOptions.upgrader(resp.Schema)),
+		1: upgradeResourceID[GrantBulkObjectPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GrantBulkObjectPrivilegesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.grant(ctx, &data, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, diags := data.resourceID(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	tflog.Trace(ctx, "granted bulk object privileges")
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GrantBulkObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	matching, diags := r.matchingObjects(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	granted, err := r.client.GetGrantedObjects(data.Principal.ValueString(), data.Owner.ValueString(), privileges, data.ContainerScope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read object privileges, got error: %s", err))
+		return
+	}
+
+	// Only objects that still match and hold every privilege are in state, so
+	// new objects are planned as additions on the next apply.
+	var objects []string
+	resp.Diagnostics.Append(matching.ElementsAs(ctx, &objects, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	complete := []string{}
+	for _, object := range objects {
+		if oracle.ContainsFold(granted, object) {
+			complete = append(complete, object)
+		}
+	}
+
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(data.Principal.ValueString()))
+	}
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+	data.Objects, diags = types.SetValueFrom(ctx, types.StringType, complete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state GrantBulkObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := objectPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.grant(ctx, &data, managed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke the previous privileges from objects that no longer match, and
+	// privileges that were removed from the objects that still match.
+	var stateObjects, planObjects []string
+	resp.Diagnostics.Append(state.Objects.ElementsAs(ctx, &stateObjects, false)...)
+	resp.Diagnostics.Append(data.Objects.ElementsAs(ctx, &planObjects, false)...)
+	statePrivileges, diags := objectPrivilegeOptions.privileges(ctx, state.Privileges)
+	resp.Diagnostics.Append(diags...)
+	planPrivileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revokes := []oracle.BulkObjectPrivilege{
+		{Objects: difference(stateObjects, planObjects), Privileges: statePrivileges, Managed: managed},
+		{Objects: planObjects, Privileges: removedPrivileges(statePrivileges, planPrivileges), Managed: managed},
+	}
+	for _, revoke := range revokes {
+		if len(revoke.Objects) == 0 || len(revoke.Privileges) == 0 {
+			continue
+		}
+		revoke.Principal = data.Principal.ValueString()
+		revoke.Owner = data.Owner.ValueString()
+		revoke.ContainerScope = data.ContainerScope.ValueString()
+		if err := r.client.RevokeBulkObjectPrivileges(revoke); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke object privileges, got error: %s", err))
+			return
+		}
+	}
+
+	id, diags := data.resourceID(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantBulkObjectPrivilegesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GrantBulkObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var objects []string
+	resp.Diagnostics.Append(data.Objects.ElementsAs(ctx, &objects, false)...)
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := objectPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revoke := oracle.BulkObjectPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Owner:          data.Owner.ValueString(),
+		Objects:        objects,
+		Privileges:     privileges,
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	err := r.client.RevokeBulkObjectPrivileges(revoke)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke object privileges, got error: %s", err))
+		return
+	}
+}
+
+// matchingObjects returns the objects that currently match the owner, object types and patterns of the model.
+func (r *GrantBulkObjectPrivilegesResource) matchingObjects(ctx context.Context, data GrantBulkObjectPrivilegesResourceModel) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var objectTypes []string
+	diags.Append(data.ObjectTypes.ElementsAs(ctx, &objectTypes, false)...)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+
+	objects, err := r.client.MatchingObjects(data.Owner.ValueString(), objectTypes, data.IncludePattern.ValueString(), data.ExcludePattern.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read matching objects, got error: %s", err))
+		return types.SetNull(types.StringType), diags
+	}
+
+	set, setDiags := types.SetValueFrom(ctx, types.StringType, objects)
+	diags.Append(setDiags...)
+	return set, diags
+}
+
+// grant grants the privileges on the planned objects. If the objects could
+// not be planned, they are looked up first. For PUBLIC, enforce mode only
+// revokes the managed privileges.
+func (r *GrantBulkObjectPrivilegesResource) grant(ctx context.Context, data *GrantBulkObjectPrivilegesResourceModel, managed []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Objects.IsUnknown() || data.Objects.IsNull() {
+		objects, matchDiags := r.matchingObjects(ctx, *data)
+		diags.Append(matchDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		data.Objects = objects
+	}
+
+	var objects []string
+	diags.Append(data.Objects.ElementsAs(ctx, &objects, false)...)
+	privileges, privilegeDiags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	diags.Append(privilegeDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	grant := oracle.BulkObjectPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Owner:          data.Owner.ValueString(),
+		Objects:        objects,
+		Privileges:     privileges,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	if err := r.client.GrantBulkObjectPrivileges(grant); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to grant object privileges, got error: %s", err))
+	}
+	return diags
+}
+
+// difference returns the values of a that are not in b, ignoring case.
+func difference(a, b []string) []string {
+	result := []string{}
+	for _, value := range a {
+		if !oracle.ContainsFold(b, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// removedPrivileges returns the privileges of prior whose name is not in planned.
+func removedPrivileges(prior, planned []oracle.Privilege) []oracle.Privilege {
+	removed := []oracle.Privilege{}
+	for _, privilege := range prior {
+		found := false
+		for _, plannedPrivilege := range planned {
+			if strings.EqualFold(plannedPrivilege.Name, privilege.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			removed = append(removed, privilege)
+		}
+	}
+	return removed
+}
+
+// bulkObjectPrivilegesKind is the kind of the identifier of bulk object privilege grants.
+const bulkObjectPrivilegesKind = "bulk_object_privileges"
+
+// resourceID returns the identifier of bulk object privilege grants, e.g.
+// "bulk_object_privileges:app_user:hr:TABLE,VIEW:*::current". The object
+// types and patterns are part of the identifier so that several resources can
+// grant privileges on the objects of one schema to one principal.
+func (data GrantBulkObjectPrivilegesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	var objectTypes []string
+	diags := data.ObjectTypes.ElementsAs(ctx, &objectTypes, false)
+	sort.Strings(objectTypes)
+	return formatResourceID(bulkObjectPrivilegesKind,
+		data.Principal.ValueString(),
+		data.Owner.ValueString(),
+		joinIDList(objectTypes),
+		data.IncludePattern.ValueString(),
+		data.ExcludePattern.ValueString(),
+		containerScopeValue(data.ContainerScope).ValueString(),
+	), diags
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_GrantBulkObjectPrivilegesResource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	ownerName := "test_owner_" + randString
+	setupTestTableForOwner(t, "app_orders", ownerName)
+	config := providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_bulk_object_privileges" "test_grant" {
+  principal       = oracle_user.test_user.username
+  owner           = "%s"
+  object_types    = ["TABLE"]
+  include_pattern = "APP_*"
+  exclude_pattern = "*_STAGING"
+  privileges      = [{ privilege = "SELECT" }]
+}
+`, randString, ownerName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_bulk_object_privileges.test_grant", "objects.#", "1"),
+					resource.TestCheckTypeSetElemAttr("oracle_grant_bulk_object_privileges.test_grant", "objects.*", "APP_ORDERS"),
+				),
+			},
+			// New matching objects are granted on the next apply, excluded ones are not
+			{
+				PreConfig: func() {
+					db, err := getTestDB()
+					if err != nil {
+						t.Fatalf("Failed to connect to the database: %v", err)
+					}
+					defer db.Close()
+
+					for _, table := range []string{"app_customers", "app_orders_staging", "audit_log"} {
+						if _, err := db.Exec(fmt.Sprintf(createTableSQL, ownerName+"."+table)); err != nil {
+							t.Fatalf("Failed to create test table: %v", err)
+						}
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_bulk_object_privileges.test_grant", "objects.#", "2"),
+					resource.TestCheckTypeSetElemAttr("oracle_grant_bulk_object_privileges.test_grant", "objects.*", "APP_CUSTOMERS"),
+				),
+			},
+			// Dropped objects are removed from state
+			{
+				PreConfig: func() {
+					db, err := getTestDB()
+					if err != nil {
+						t.Fatalf("Failed to connect to the database: %v", err)
+					}
+					defer db.Close()
+
+					if _, err := db.Exec(fmt.Sprintf(dropTableSQL, ownerName+".app_customers") + " PURGE"); err != nil {
+						t.Fatalf("Failed to drop test table: %v", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_bulk_object_privileges.test_grant", "objects.#", "1"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckNoGrants("dba_tab_privs", fmt.Sprintf("testuser_%s", randString)),
+	})
+	t.Cleanup(func() {
+		tearDownTestTableForOwner(t, "app_orders", ownerName)
+	})
+}
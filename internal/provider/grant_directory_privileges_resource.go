@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,6 +24,8 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GrantDirectoryPrivilegesResource{}
 var _ resource.ResourceWithImportState = &GrantDirectoryPrivilegesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantDirectoryPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantDirectoryPrivilegesResource{}
 
 func NewGrantDirectoryPrivilegesResource() resource.Resource {
 	return &GrantDirectoryPrivilegesResource{}
@@ -34,11 +38,17 @@ type GrantDirectoryPrivilegesResource struct {
 
 // GrantDirectoryPrivilegesResourceModel describes the resource data model.
 type GrantDirectoryPrivilegesResourceModel struct {
-	Principal  types.String `tfsdk:"principal"`
-	Directory  types.String `tfsdk:"directory"`
-	Privileges types.Set    `tfsdk:"privileges"`
-	GrantsMode types.String `tfsdk:"grants_mode"`
-	ID         types.String `tfsdk:"id"`
+	Principal             types.String `tfsdk:"principal"`
+	Principals            types.Set    `tfsdk:"principals"`
+	Directory             types.String `tfsdk:"directory"`
+	Privileges            types.Set    `tfsdk:"privileges"`
+	GrantsMode            types.String `tfsdk:"grants_mode"`
+	ContainerScope        types.String `tfsdk:"container_scope"`
+	AllowOracleMaintained types.Bool   `tfsdk:"allow_oracle_maintained"`
+	AllowPublic           types.Bool   `tfsdk:"allow_public"`
+	ToGrant               types.Set    `tfsdk:"to_grant"`
+	ToRevoke              types.Set    `tfsdk:"to_revoke"`
+	ID                    types.String `tfsdk:"id"`
 }
 
 func (r *GrantDirectoryPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,25 +58,30 @@ func (r *GrantDirectoryPrivilegesResource) Metadata(ctx context.Context, req res
 func (r *GrantDirectoryPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A resource to manage directory privileges for a user or role.",
+		Version:             2,
 
 		Attributes: map[string]schema.Attribute{
-			"principal": schema.StringAttribute{
-				MarkdownDescription: "The user or role to whom the privileges are granted.",
-				Required:            true,
-			},
+			"principal":  principalAttribute(),
+			"principals": principalsAttribute(),
 			"directory": schema.StringAttribute{
-				MarkdownDescription: "The name of the directory object.",
-				Required:            true,
-			},
-			"privileges": schema.SetAttribute{
-				MarkdownDescription: "The privileges to grant on the directory. Possible values are `READ` and `WRITE`.",
-				ElementType:         types.StringType,
+				MarkdownDescription: "The name of the directory object. Changing this revokes the grants on the previous directory.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
 			},
+			"privileges": directoryPrivilegeOptions.attribute("The privileges to grant on the directory. Possible values of `privilege` are `READ`, `WRITE` and `EXECUTE`."),
 			"grants_mode": schema.StringAttribute{
 				MarkdownDescription: "The grants mode to use. If not specified, the default is `append`.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
 			},
+			"container_scope":         containerScopeAttribute("The container scope of the grant."),
+			"allow_oracle_maintained": allowOracleMaintainedAttribute(),
+			"allow_public":            allowPublicAttribute(),
+			"to_grant":                toGrantAttribute("privileges"),
+			"to_revoke":               toRevokeAttribute("privileges"),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Grant identifier",
@@ -78,6 +93,72 @@ func (r *GrantDirectoryPrivilegesResource) Schema(ctx context.Context, req resou
 	}
 }
 
+// ModifyPlan checks the privilege names against the privilege catalog of the
+// database and previews the privileges that are granted and revoked, so that
+// typos and destructive changes are visible before apply.
+func (r *GrantDirectoryPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to preview when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planResourceID(ctx, req, resp, "principal", "principals", "directory", "container_scope")
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var data GrantDirectoryPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := directoryPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := directoryPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(directoryPrivilegeOptions.checkCatalog(r.client, privileges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := planGrants(data.Principal, principals, func(principal string) (oracle.GrantPlan, error) {
+		return r.client.PlanDirectoryPrivileges(oracle.DirectoryPrivilege{
+			Principal:      principal,
+			Directory:      data.Directory.ValueString(),
+			Privileges:     privileges,
+			GrantsMode:     data.GrantsMode.ValueString(),
+			ContainerScope: data.ContainerScope.ValueString(),
+			Managed:        managed,
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read directory privileges, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, strings.Join(principals, ", "), &plan)
+}
+
+func (r *GrantDirectoryPrivilegesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantDirectoryPrivilegesResourceModel](directoryPrivilegeOptions.upgrader(resp.Schema)),
+		1: upgradeResourceID[GrantDirectoryPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
 func (r *GrantDirectoryPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -108,29 +189,35 @@ func (r *GrantDirectoryPrivilegesResource) Create(ctx context.Context, req resou
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	privileges, diags := directoryPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.DirectoryPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Directory:  data.Directory.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Directory:             data.Directory.ValueString(),
+		Privileges:            privileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
 	}
 
-	err := r.client.GrantDirectoryPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant directory privileges, got error: %s", err))
 		return
 	}
 
-	data.ID = types.StringValue(fmt.Sprintf("%s:%s", data.Principal.ValueString(), data.Directory.ValueString()))
+	data.ID = types.StringValue(directoryPrivilegesID(principals, data.Directory.ValueString(), data.ContainerScope.ValueString()))
 
 	tflog.Trace(ctx, "granted directory privileges")
 
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -143,20 +230,46 @@ func (r *GrantDirectoryPrivilegesResource) Read(ctx context.Context, req resourc
 		return
 	}
 
-	parts := strings.Split(data.ID.ValueString(), ":")
-	principal := parts[0]
+	parts, err := parseResourceID(data.ID.ValueString(), directoryPrivilegesKind, "principals:directory:container_scope")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Resource Identifier", err.Error())
+		return
+	}
+	principals := splitIDList(parts[0])
 	directory := parts[1]
 
-	privileges, err := r.client.GetCurrentDirectoryPrivileges(principal, directory)
-	if err != nil {
-		// If the grant is not found, remove it from state
-		resp.State.RemoveResource(ctx)
+	priorPrivileges, diags := directoryPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Principal = types.StringValue(principal)
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	var current [][]oracle.Privilege
+	for _, principal := range principals {
+		privileges, err := r.client.GetCurrentDirectoryPrivileges(principal, directory, data.ContainerScope.ValueString())
+		if err != nil {
+			// If the grant is not found, remove it from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		current = append(current, withoutUnmanagedPublic(principal, privileges, priorPrivileges, data.Privileges.IsNull()))
+	}
+
+	if data.AllowOracleMaintained.IsNull() {
+		data.AllowOracleMaintained = types.BoolValue(false)
+	}
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(principals[0]))
+	}
+	if data.Principals.IsNull() {
+		data.Principal = types.StringValue(principals[0])
+	}
 	data.Directory = types.StringValue(directory)
-	data.Privileges, resp.Diagnostics = types.SetValueFrom(ctx, types.StringType, privileges)
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+	data.Privileges, diags = directoryPrivilegeOptions.value(ctx, commonPrivileges(current, priorPrivileges), data.Privileges)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -165,33 +278,58 @@ func (r *GrantDirectoryPrivilegesResource) Read(ctx context.Context, req resourc
 }
 
 func (r *GrantDirectoryPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data GrantDirectoryPrivilegesResourceModel
+	var data, state GrantDirectoryPrivilegesResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	privileges, diags := directoryPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := directoryPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrincipals, diags := grantPrincipals(ctx, state.Principal, state.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrivileges, diags := directoryPrivilegeOptions.privileges(ctx, state.Privileges)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.DirectoryPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Directory:  data.Directory.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Directory:             data.Directory.ValueString(),
+		Privileges:            privileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
+		Managed:               managed,
 	}
 
-	err := r.client.GrantDirectoryPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update directory privileges, got error: %s", err))
 		return
 	}
 
+	// Revoke the grants of the principals that were removed
+	if removed := difference(statePrincipals, principals); len(removed) > 0 {
+		grant.Privileges = statePrivileges
+		if err := r.client.RevokeDirectoryPrivilegesFromPrincipals(grant, removed); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke directory privileges, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(directoryPrivilegesID(principals, data.Directory.ValueString(), data.ContainerScope.ValueString()))
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -204,14 +342,23 @@ func (r *GrantDirectoryPrivilegesResource) Delete(ctx context.Context, req resou
 		return
 	}
 
+	privileges, diags := directoryPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke only the privileges in state, so that the grants of other
+	// resources and those made outside of Terraform are left alone
 	grant := oracle.DirectoryPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Directory:  data.Directory.ValueString(),
-		Privileges: []string{},
-		GrantsMode: "enforce",
+		Directory:      data.Directory.ValueString(),
+		Privileges:     privileges,
+		ContainerScope: data.ContainerScope.ValueString(),
 	}
 
-	err := r.client.GrantDirectoryPrivileges(grant)
+	err := r.client.RevokeDirectoryPrivilegesFromPrincipals(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke directory privileges, got error: %s", err))
 		return
@@ -219,5 +366,38 @@ func (r *GrantDirectoryPrivilegesResource) Delete(ctx context.Context, req resou
 }
 
 func (r *GrantDirectoryPrivilegesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts, err := parseImportID(req.ID, directoryPrivilegesKind, "principals:directory:container_scope", "principals:directory", "container_scope")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	principals, containerScope := splitIDList(parts[0]), importedContainerScope(parts[2])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), directoryPrivilegesID(principals, parts[1], containerScope))...)
+	resp.Diagnostics.Append(importPrincipals(ctx, &resp.State, principals)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("directory"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grants_mode"), "append")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_scope"), containerScope)...)
+}
+
+// grant grants directory privileges to the principal, or to each of several principals.
+func (r *GrantDirectoryPrivilegesResource) grant(privilege oracle.DirectoryPrivilege, principals []string) error {
+	if privilege.Principal != "" {
+		return r.client.GrantDirectoryPrivileges(privilege)
+	}
+	return r.client.GrantDirectoryPrivilegesToPrincipals(privilege, principals)
+}
+
+// directoryPrivilegesKind is the kind of the identifier of directory privilege grants.
+const directoryPrivilegesKind = "directory_privileges"
+
+// directoryPrivilegesID returns the identifier of directory privilege grants,
+// e.g. "directory_privileges:app_user:data_dir:current".
+func directoryPrivilegesID(principals []string, directory, containerScope string) string {
+	return formatResourceID(directoryPrivilegesKind, joinIDList(principals), directory, containerScope)
+}
+
+func (data GrantDirectoryPrivilegesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	return directoryPrivilegesID(principals, data.Directory.ValueString(), containerScopeValue(data.ContainerScope).ValueString()), diags
 }
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -32,14 +33,14 @@ resource "oracle_directory" "test_dir" {
 resource "oracle_grant_directory_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
   directory  = oracle_directory.test_dir.name
-  privileges = toset(["READ"])
+  privileges = [{ privilege = "READ" }]
 }
 `, randString, randString),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "principal", fmt.Sprintf("testuser_%s", randString)),
 					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "directory", fmt.Sprintf("testdir_%s", randString)),
 					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "privileges.#", "1"),
-					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "privileges.0", "READ"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_directory_privileges.test_grant", "privileges.*", map[string]string{"privilege": "READ"}),
 				),
 			},
 			// ImportState testing
@@ -47,6 +48,19 @@ resource "oracle_grant_directory_privileges" "test_grant" {
 				ResourceName:      "oracle_grant_directory_privileges.test_grant",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:    "oracle_grant_directory_privileges.test_grant",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			{
+				ResourceName:  "oracle_grant_directory_privileges.test_grant",
+				ImportState:   true,
+				ImportStateId: "principal_only",
+				ExpectError:   regexp.MustCompile(`Unexpected Import Identifier`),
 			},
 			// Update and Read testing
 			{
@@ -64,13 +78,13 @@ resource "oracle_directory" "test_dir" {
 resource "oracle_grant_directory_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
   directory  = oracle_directory.test_dir.name
-  privileges = ["READ", "WRITE"]
+  privileges = [{ privilege = "READ" }, { privilege = "WRITE" }]
 }
 `, randString, randString),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "privileges.#", "2"),
-					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "privileges.0", "READ"),
-					resource.TestCheckResourceAttr("oracle_grant_directory_privileges.test_grant", "privileges.1", "WRITE"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_directory_privileges.test_grant", "privileges.*", map[string]string{"privilege": "READ"}),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_directory_privileges.test_grant", "privileges.*", map[string]string{"privilege": "WRITE"}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
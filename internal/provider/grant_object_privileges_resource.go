@@ -6,13 +6,22 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -22,6 +31,9 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GrantObjectPrivilegesResource{}
 var _ resource.ResourceWithImportState = &GrantObjectPrivilegesResource{}
+var _ resource.ResourceWithValidateConfig = &GrantObjectPrivilegesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantObjectPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantObjectPrivilegesResource{}
 
 func NewGrantObjectPrivilegesResource() resource.Resource {
 	return &GrantObjectPrivilegesResource{}
@@ -34,12 +46,20 @@ type GrantObjectPrivilegesResource struct {
 
 // GrantObjectPrivilegesResourceModel describes the resource data model.
 type GrantObjectPrivilegesResourceModel struct {
-	Principal  types.String `tfsdk:"principal"`
-	Object     types.String `tfsdk:"object"`
-	Owner      types.String `tfsdk:"owner"`
-	Privileges types.Set    `tfsdk:"privileges"`
-	GrantsMode types.String `tfsdk:"grants_mode"`
-	ID         types.String `tfsdk:"id"`
+	Principal             types.String `tfsdk:"principal"`
+	Principals            types.Set    `tfsdk:"principals"`
+	Object                types.String `tfsdk:"object"`
+	Owner                 types.String `tfsdk:"owner"`
+	ObjectType            types.String `tfsdk:"object_type"`
+	Privileges            types.Set    `tfsdk:"privileges"`
+	ColumnPrivileges      types.Map    `tfsdk:"column_privileges"`
+	GrantsMode            types.String `tfsdk:"grants_mode"`
+	ContainerScope        types.String `tfsdk:"container_scope"`
+	AllowOracleMaintained types.Bool   `tfsdk:"allow_oracle_maintained"`
+	AllowPublic           types.Bool   `tfsdk:"allow_public"`
+	ToGrant               types.Set    `tfsdk:"to_grant"`
+	ToRevoke              types.Set    `tfsdk:"to_revoke"`
+	ID                    types.String `tfsdk:"id"`
 }
 
 func (r *GrantObjectPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,29 +69,66 @@ func (r *GrantObjectPrivilegesResource) Metadata(ctx context.Context, req resour
 func (r *GrantObjectPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A resource to manage object privileges for a user",
+		Version:             2,
 
 		Attributes: map[string]schema.Attribute{
-			"principal": schema.StringAttribute{
-				MarkdownDescription: "The user or role to whom the privileges are granted.",
-				Required:            true,
-			},
+			"principal":  principalAttribute(),
+			"principals": principalsAttribute(),
 			"object": schema.StringAttribute{
-				MarkdownDescription: "The name of the object.",
+				MarkdownDescription: "The name of the object. Changing this revokes the grants on the previous object.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
 			},
 			"owner": schema.StringAttribute{
-				MarkdownDescription: "The owner of the object.",
+				MarkdownDescription: "The owner of the object. Changing this revokes the grants on the previous object.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
 			},
-			"privileges": schema.SetAttribute{
-				MarkdownDescription: "The privileges to grant on the object.",
-				ElementType:         types.StringType,
-				Required:            true,
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the object, e.g. `TABLE`, `SEQUENCE` or `PACKAGE`. When set, the object is checked to exist with this type and only grants on an object of this type are read. " +
+					"`EDITION` (`GRANT USE ON EDITION`), `USER` (`GRANT INHERIT PRIVILEGES ON USER`), `JAVA SOURCE`, `JAVA RESOURCE` and `MINING MODEL` use their type-specific grant syntax. " +
+					"`owner` must not be set for editions and users. Changing this revokes the grants on the previous object.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(objectTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					// Setting the type of an imported grant does not change the object.
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull() && !strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+						},
+						"Changing the object type requires replacing the resource.",
+						"Changing the object type requires replacing the resource.",
+					),
+				},
+			},
+			"privileges": objectPrivilegeAttribute(),
+			"column_privileges": schema.MapAttribute{
+				MarkdownDescription: "Column-level privileges to grant on the object, mapping `INSERT`, `UPDATE` or `REFERENCES` to the set of columns they are granted on, " +
+					"e.g. `{ UPDATE = [\"email\", \"phone\"] }`. In `enforce` mode, Oracle can only revoke a column-level privilege from all columns at once, " +
+					"so removing a column revokes the privilege and grants it again on the remaining columns.",
+				ElementType: types.SetType{ElemType: types.StringType},
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf("INSERT", "UPDATE", "REFERENCES")),
+				},
 			},
 			"grants_mode": schema.StringAttribute{
 				MarkdownDescription: "The grants mode to use. If not specified, the default is `append`.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
 			},
+			"container_scope":         containerScopeAttribute("The container scope of the grant."),
+			"allow_oracle_maintained": allowOracleMaintainedAttribute(),
+			"allow_public":            allowPublicAttribute(),
+			"to_grant":                toGrantAttribute("privileges"),
+			"to_revoke":               toRevokeAttribute("privileges"),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Grant identifier",
@@ -83,6 +140,105 @@ func (r *GrantObjectPrivilegesResource) Schema(ctx context.Context, req resource
 	}
 }
 
+// ModifyPlan checks the privilege names against the privilege catalog of the
+// database and previews the privileges that are granted and revoked, so that
+// typos and destructive changes are visible before apply.
+func (r *GrantObjectPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to preview when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planResourceID(ctx, req, resp, "principal", "principals", "owner", "object", "object_type", "container_scope")
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var data GrantObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var columnPrivileges map[string][]string
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.ColumnPrivileges.ElementsAs(ctx, &columnPrivileges, false)...)
+	managed, diags := managedObjectPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(objectPrivilegeOptions.checkCatalog(r.client, privileges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := planGrants(data.Principal, principals, func(principal string) (oracle.GrantPlan, error) {
+		return r.client.PlanObjectPrivileges(oracle.ObjectPrivilege{
+			Principal:        principal,
+			Object:           data.Object.ValueString(),
+			Owner:            data.Owner.ValueString(),
+			ObjectType:       data.ObjectType.ValueString(),
+			Privileges:       privileges,
+			ColumnPrivileges: columnPrivileges,
+			GrantsMode:       data.GrantsMode.ValueString(),
+			ContainerScope:   data.ContainerScope.ValueString(),
+			Managed:          managed,
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read object privileges, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, strings.Join(principals, ", "), &plan)
+}
+
+func (r *GrantObjectPrivilegesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantObjectPrivilegesResourceModel](objectPrivilegeOptions.upgrader(resp.Schema)),
+		1: upgradeResourceID[GrantObjectPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
+func (r *GrantObjectPrivilegesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GrantObjectPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objectType := data.ObjectType.ValueString()
+	if (objectType == "EDITION" || objectType == "USER") && !data.Owner.IsNull() && !data.Owner.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("owner"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("owner cannot be set when object_type is %q.", objectType),
+		)
+	}
+
+	if !data.ObjectType.IsNull() && !data.ObjectType.IsUnknown() && !slices.Contains(columnObjectTypes, objectType) &&
+		!data.ColumnPrivileges.IsNull() && !data.ColumnPrivileges.IsUnknown() && len(data.ColumnPrivileges.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("column_privileges"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("column_privileges can only be set for tables and views, not when object_type is %q.", objectType),
+		)
+	}
+}
+
 func (r *GrantObjectPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -113,30 +269,45 @@ func (r *GrantObjectPrivilegesResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	var columnPrivileges map[string][]string
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.ColumnPrivileges.ElementsAs(ctx, &columnPrivileges, false)...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.ObjectPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Object:     data.Object.ValueString(),
-		Owner:      data.Owner.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Object:                data.Object.ValueString(),
+		Owner:                 data.Owner.ValueString(),
+		ObjectType:            data.ObjectType.ValueString(),
+		Privileges:            privileges,
+		ColumnPrivileges:      columnPrivileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
+	}
+
+	resp.Diagnostics.Append(r.checkObjectType(grant)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	err := r.client.GrantObjectPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant object privileges, got error: %s", err))
 		return
 	}
 
-	data.ID = types.StringValue(fmt.Sprintf("%s:%s:%s", data.Principal.ValueString(), data.Owner.ValueString(), data.Object.ValueString()))
+	data.ID = types.StringValue(objectPrivilegesID(principals, data.Owner.ValueString(), data.Object.ValueString(), data.ObjectType.ValueString(), data.ContainerScope.ValueString()))
 
 	tflog.Trace(ctx, "granted object privileges")
 
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -149,22 +320,69 @@ func (r *GrantObjectPrivilegesResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	parts := strings.Split(data.ID.ValueString(), ":")
-	principal := parts[0]
+	parts, err := parseResourceID(data.ID.ValueString(), objectPrivilegesKind, "principals:owner:object:object_type:container_scope", "owner", "object_type")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Resource Identifier", err.Error())
+		return
+	}
+	principals := splitIDList(parts[0])
 	owner := parts[1]
 	object := parts[2]
 
-	privileges, err := r.client.GetCurrentObjectPrivileges(principal, owner, object)
-	if err != nil {
-		// If the grant is not found, remove it from state
-		resp.State.RemoveResource(ctx)
+	var priorColumnPrivileges map[string][]string
+	priorPrivileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.ColumnPrivileges.ElementsAs(ctx, &priorColumnPrivileges, false)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Principal = types.StringValue(principal)
-	data.Owner = types.StringValue(owner)
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	imported := data.Privileges.IsNull()
+	var current [][]oracle.Privilege
+	var currentColumns []map[string][]string
+	for _, principal := range principals {
+		privileges, err := r.client.GetCurrentObjectPrivileges(principal, owner, object, data.ObjectType.ValueString(), data.ContainerScope.ValueString())
+		if err != nil {
+			// If the grant is not found, remove it from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		columnPrivileges, err := r.client.GetCurrentColumnPrivileges(principal, owner, object, data.ContainerScope.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read column privileges, got error: %s", err))
+			return
+		}
+
+		if oracle.IsPublic(principal) && !imported {
+			for privilege := range columnPrivileges {
+				if _, ok := data.ColumnPrivileges.Elements()[privilege]; !ok {
+					delete(columnPrivileges, privilege)
+				}
+			}
+		}
+		current = append(current, withoutUnmanagedPublic(principal, privileges, priorPrivileges, imported))
+		currentColumns = append(currentColumns, columnPrivileges)
+	}
+
+	if data.AllowOracleMaintained.IsNull() {
+		data.AllowOracleMaintained = types.BoolValue(false)
+	}
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(principals[0]))
+	}
+	if data.Principals.IsNull() {
+		data.Principal = types.StringValue(principals[0])
+	}
+	data.Owner = optionalString(owner)
 	data.Object = types.StringValue(object)
-	data.Privileges, resp.Diagnostics = types.SetValueFrom(ctx, types.StringType, privileges)
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+	data.Privileges, diags = objectPrivilegeOptions.value(ctx, commonPrivileges(current, priorPrivileges), data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	data.ColumnPrivileges, diags = columnPrivilegesValue(ctx, commonColumnPrivileges(currentColumns, priorColumnPrivileges), data.ColumnPrivileges)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -173,35 +391,72 @@ func (r *GrantObjectPrivilegesResource) Read(ctx context.Context, req resource.R
 }
 
 func (r *GrantObjectPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data GrantObjectPrivilegesResourceModel
+	var data, state GrantObjectPrivilegesResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	var columnPrivileges map[string][]string
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.ColumnPrivileges.ElementsAs(ctx, &columnPrivileges, false)...)
+	managed, diags := managedObjectPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrincipals, diags := grantPrincipals(ctx, state.Principal, state.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrivileges, diags := objectPrivilegeOptions.privileges(ctx, state.Privileges)
+	resp.Diagnostics.Append(diags...)
+	var stateColumnPrivileges map[string][]string
+	resp.Diagnostics.Append(state.ColumnPrivileges.ElementsAs(ctx, &stateColumnPrivileges, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.ObjectPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Object:     data.Object.ValueString(),
-		Owner:      data.Owner.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Object:                data.Object.ValueString(),
+		Owner:                 data.Owner.ValueString(),
+		ObjectType:            data.ObjectType.ValueString(),
+		Privileges:            privileges,
+		ColumnPrivileges:      columnPrivileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
+		Managed:               managed,
+	}
+
+	resp.Diagnostics.Append(r.checkObjectType(grant)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	err := r.client.GrantObjectPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update object privileges, got error: %s", err))
 		return
 	}
 
+	// Revoke the grants of the principals that were removed
+	if removed := difference(statePrincipals, principals); len(removed) > 0 {
+		grant.Privileges = statePrivileges
+		grant.ColumnPrivileges = stateColumnPrivileges
+		if err := r.client.RevokeObjectPrivilegesFromPrincipals(grant, removed); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke object privileges, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(objectPrivilegesID(principals, data.Owner.ValueString(), data.Object.ValueString(), data.ObjectType.ValueString(), data.ContainerScope.ValueString()))
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,15 +469,28 @@ func (r *GrantObjectPrivilegesResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	var columnPrivileges map[string][]string
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.ColumnPrivileges.ElementsAs(ctx, &columnPrivileges, false)...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke only the privileges in state, so that the grants of other
+	// resources and those made outside of Terraform are left alone
 	grant := oracle.ObjectPrivilege{
-		Principal:  data.Principal.ValueString(),
-		Object:     data.Object.ValueString(),
-		Owner:      data.Owner.ValueString(),
-		Privileges: []string{},
-		GrantsMode: "enforce",
+		Object:           data.Object.ValueString(),
+		Owner:            data.Owner.ValueString(),
+		ObjectType:       data.ObjectType.ValueString(),
+		Privileges:       privileges,
+		ColumnPrivileges: columnPrivileges,
+		ContainerScope:   data.ContainerScope.ValueString(),
 	}
 
-	err := r.client.GrantObjectPrivileges(grant)
+	err := r.client.RevokeObjectPrivilegesFromPrincipals(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke object privileges, got error: %s", err))
 		return
@@ -230,5 +498,136 @@ func (r *GrantObjectPrivilegesResource) Delete(ctx context.Context, req resource
 }
 
 func (r *GrantObjectPrivilegesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts, err := parseImportID(req.ID, objectPrivilegesKind, "principals:owner:object:object_type:container_scope", "principals:owner:object", "owner", "object_type", "container_scope")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	principals, containerScope := splitIDList(parts[0]), importedContainerScope(parts[4])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), objectPrivilegesID(principals, parts[1], parts[2], parts[3], containerScope))...)
+	resp.Diagnostics.Append(importPrincipals(ctx, &resp.State, principals)...)
+	if parts[1] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), parts[1])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object"), parts[2])...)
+	if parts[3] != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("object_type"), parts[3])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grants_mode"), "append")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_scope"), containerScope)...)
+}
+
+// grant grants object privileges to the principal, or to each of several principals.
+func (r *GrantObjectPrivilegesResource) grant(privilege oracle.ObjectPrivilege, principals []string) error {
+	if privilege.Principal != "" {
+		return r.client.GrantObjectPrivileges(privilege)
+	}
+	return r.client.GrantObjectPrivilegesToPrincipals(privilege, principals)
+}
+
+// objectPrivilegeAttribute returns the schema of the privileges attribute of
+// object grants, which defaults to no privileges.
+func objectPrivilegeAttribute() schema.SetNestedAttribute {
+	attribute := objectPrivilegeOptions.attribute("The privileges to grant on the object, e.g. `{ privilege = \"SELECT\", with_grant_option = true }`. If not specified, no table-level privileges are granted.")
+	attribute.Optional = true
+	attribute.Computed = true
+	attribute.Default = setdefault.StaticValue(types.SetValueMust(objectPrivilegeOptions.objectType(), []attr.Value{}))
+	return attribute
+}
+
+// objectTypes are the supported values of the object_type attribute.
+var objectTypes = []string{
+	"TABLE", "VIEW", "MATERIALIZED VIEW", "SEQUENCE", "PROCEDURE", "FUNCTION", "PACKAGE", "TYPE",
+	"LIBRARY", "OPERATOR", "INDEXTYPE", "JAVA CLASS", "JAVA SOURCE", "JAVA RESOURCE", "MINING MODEL", "EDITION", "USER",
+}
+
+// columnObjectTypes are the object types that support column-level privileges.
+var columnObjectTypes = []string{"TABLE", "VIEW", "MATERIALIZED VIEW"}
+
+// checkObjectType checks that the object of a grant exists with the configured object type.
+func (r *GrantObjectPrivilegesResource) checkObjectType(grant oracle.ObjectPrivilege) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if grant.ObjectType == "" {
+		return diags
+	}
+
+	exists, err := r.client.ObjectExists(grant.Owner, grant.Object, grant.ObjectType)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to check object %s, got error: %s", grant.Object, err))
+		return diags
+	}
+	if !exists {
+		diags.AddAttributeError(
+			path.Root("object_type"),
+			"Object Not Found",
+			fmt.Sprintf("No %s named %s exists in the database.", strings.ToLower(grant.ObjectType), grant.Object),
+		)
+	}
+	return diags
+}
+
+// managedObjectPrivileges returns the names of the table-level and
+// column-level privileges stored in the prior state of an object grant.
+func managedObjectPrivileges(ctx context.Context, state tfsdk.State) ([]string, diag.Diagnostics) {
+	managed, diags := objectPrivilegeOptions.managedPrivileges(ctx, state)
+	if diags.HasError() || state.Raw.IsNull() {
+		return managed, diags
+	}
+
+	var columnPrivileges types.Map
+	diags.Append(state.GetAttribute(ctx, path.Root("column_privileges"), &columnPrivileges)...)
+	for privilege := range columnPrivileges.Elements() {
+		managed = append(managed, privilege)
+	}
+	return managed, diags
+}
+
+// columnPrivilegesValue converts the column privileges read from the database
+// to the column_privileges attribute. Column names keep the spelling of the
+// prior value when they only differ in case, and no column privileges are
+// stored as null unless the prior value was an empty map.
+func columnPrivilegesValue(ctx context.Context, current map[string][]string, prior types.Map) (types.Map, diag.Diagnostics) {
+	elementType := types.SetType{ElemType: types.StringType}
+	if len(current) == 0 && (prior.IsNull() || prior.IsUnknown() || len(prior.Elements()) > 0) {
+		return types.MapNull(elementType), nil
+	}
+
+	var priorPrivileges map[string][]string
+	diags := prior.ElementsAs(ctx, &priorPrivileges, false)
+	if diags.HasError() {
+		return types.MapNull(elementType), diags
+	}
+
+	privileges := map[string][]string{}
+	for privilege, columns := range current {
+		for _, column := range columns {
+			name := strings.ToLower(column)
+			for _, priorColumn := range priorPrivileges[privilege] {
+				if strings.EqualFold(priorColumn, column) {
+					name = priorColumn
+				}
+			}
+			privileges[privilege] = append(privileges[privilege], name)
+		}
+	}
+
+	value, d := types.MapValueFrom(ctx, elementType, privileges)
+	diags.Append(d...)
+	return value, diags
+}
+
+// objectPrivilegesKind is the kind of the identifier of object privilege grants.
+const objectPrivilegesKind = "object_privileges"
+
+// objectPrivilegesID returns the identifier of object privilege grants, e.g.
+// "object_privileges:app_user:hr:employees:TABLE:current". The owner and
+// object type are empty when they are not set.
+func objectPrivilegesID(principals []string, owner, object, objectType, containerScope string) string {
+	return formatResourceID(objectPrivilegesKind, joinIDList(principals), owner, object, objectType, containerScope)
+}
+
+func (data GrantObjectPrivilegesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	return objectPrivilegesID(principals, data.Owner.ValueString(), data.Object.ValueString(), data.ObjectType.ValueString(), containerScopeValue(data.ContainerScope).ValueString()), diags
 }
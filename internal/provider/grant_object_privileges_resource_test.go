@@ -5,10 +5,13 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAcc_GrantObjectPrivilegesResource(t *testing.T) {
@@ -34,15 +37,16 @@ resource "oracle_grant_object_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
   owner      = "%s"
   object     = "%s"
-  privileges = toset(["SELECT"])
+  privileges = [{ privilege = "SELECT" }]
 }
 `, randString, ownerName, tableName),
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "id", fmt.Sprintf("object_privileges:testuser_%s:%s:%s::current", randString, ownerName, tableName)),
 					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "principal", fmt.Sprintf("testuser_%s", randString)),
 					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "owner", ownerName),
 					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "object", tableName),
 					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.#", "1"),
-					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.0", "SELECT"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{"privilege": "SELECT"}),
 				),
 			},
 			// ImportState testing
@@ -50,6 +54,27 @@ resource "oracle_grant_object_privileges" "test_grant" {
 				ResourceName:      "oracle_grant_object_privileges.test_grant",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:    "oracle_grant_object_privileges.test_grant",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+			// Identifiers of older versions of the provider can still be imported
+			{
+				ResourceName:            "oracle_grant_object_privileges.test_grant",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("testuser_%s:%s:%s", randString, ownerName, tableName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:  "oracle_grant_object_privileges.test_grant",
+				ImportState:   true,
+				ImportStateId: "principal:owner",
+				ExpectError:   regexp.MustCompile(`Unexpected Import Identifier`),
 			},
 			// Update and Read testing
 			{
@@ -63,13 +88,13 @@ resource "oracle_grant_object_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
   owner      = "%s"
   object     = "%s"
-  privileges = toset(["SELECT", "UPDATE"])
+  privileges = [{ privilege = "SELECT" }, { privilege = "UPDATE" }]
 }
 `, randString, ownerName, tableName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.#", "2"),
-					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.0", "SELECT"),
-					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.1", "UPDATE"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{"privilege": "SELECT"}),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{"privilege": "UPDATE"}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -79,3 +104,255 @@ resource "oracle_grant_object_privileges" "test_grant" {
 		tearDownTestTableForOwner(t, tableName, ownerName)
 	})
 }
+
+func TestAcc_GrantObjectPrivilegesResource_ReplaceObject(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	ownerName := "test_owner_" + randString
+	setupTestTableForOwner(t, "test_table_a", ownerName)
+	setupTestTableForOwner(t, "test_table_b", ownerName+"_b")
+	config := func(owner, table string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_object_privileges" "test_grant" {
+  principal  = oracle_user.test_user.username
+  owner      = "%s"
+  object     = "%s"
+  privileges = [{ privilege = "SELECT" }]
+}
+`, randString, owner, table)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(ownerName, "test_table_a"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "object", "test_table_a"),
+				),
+			},
+			// Changing the owner and object replaces the grant and revokes it on the previous object
+			{
+				Config: config(ownerName+"_b", "test_table_b"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oracle_grant_object_privileges.test_grant", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "object", "test_table_b"),
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.#", "1"),
+					func(s *terraform.State) error {
+						db, err := getTestDB()
+						if err != nil {
+							return err
+						}
+						defer db.Close()
+
+						var count int
+						err = db.QueryRow("SELECT COUNT(*) FROM dba_tab_privs WHERE grantee = UPPER(:1) AND owner = UPPER(:2)", "testuser_"+randString, ownerName).Scan(&count)
+						if err != nil {
+							return err
+						}
+						if count > 0 {
+							return fmt.Errorf("expected grants on %s.test_table_a to be revoked, got %d", ownerName, count)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+	t.Cleanup(func() {
+		tearDownTestTableForOwner(t, "test_table_a", ownerName)
+		tearDownTestTableForOwner(t, "test_table_b", ownerName+"_b")
+	})
+}
+
+func TestAcc_GrantObjectPrivilegesResource_ColumnPrivileges(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tableName := "test_table_" + randString
+	ownerName := "test_owner_" + randString
+	setupTestTableForOwner(t, tableName, ownerName)
+	config := func(columnPrivileges string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_object_privileges" "test_grant" {
+  principal         = oracle_user.test_user.username
+  owner             = "%s"
+  object            = "%s"
+  privileges        = [{ privilege = "SELECT" }]
+  column_privileges = %s
+  grants_mode       = "enforce"
+}
+`, randString, ownerName, tableName, columnPrivileges)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`{ UPDATE = ["id"] }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "column_privileges.%", "1"),
+					resource.TestCheckTypeSetElemAttr("oracle_grant_object_privileges.test_grant", "column_privileges.UPDATE.*", "id"),
+				),
+			},
+			// Move the column privilege from UPDATE to INSERT
+			{
+				Config: config(`{ INSERT = ["id"] }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "column_privileges.%", "1"),
+					resource.TestCheckTypeSetElemAttr("oracle_grant_object_privileges.test_grant", "column_privileges.INSERT.*", "id"),
+				),
+			},
+			{
+				Config:      config(`{ DELETE = ["id"] }`),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+	t.Cleanup(func() {
+		tearDownTestTableForOwner(t, tableName, ownerName)
+	})
+}
+
+func TestAcc_GrantObjectPrivilegesResource_ObjectType(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tableName := "test_table_" + randString
+	ownerName := "test_owner_" + randString
+	setupTestTableForOwner(t, tableName, ownerName)
+	config := func(objectType string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_object_privileges" "test_grant" {
+  principal   = oracle_user.test_user.username
+  owner       = "%s"
+  object      = "%s"
+  object_type = "%s"
+  privileges  = [{ privilege = "SELECT" }]
+}
+
+resource "oracle_grant_object_privileges" "test_edition" {
+  principal   = oracle_user.test_user.username
+  object      = "ora$base"
+  object_type = "EDITION"
+  privileges  = [{ privilege = "USE" }]
+}
+`, randString, ownerName, tableName, objectType)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("SEQUENCE"),
+				ExpectError: regexp.MustCompile(`Object Not Found`),
+			},
+			{
+				Config: config("TABLE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "object_type", "TABLE"),
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_grant", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_object_privileges.test_edition", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_edition", "privileges.*", map[string]string{"privilege": "USE"}),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "oracle_grant_object_privileges" "test_edition" {
+  principal   = "testuser"
+  owner       = "sys"
+  object      = "ora$base"
+  object_type = "EDITION"
+  privileges  = [{ privilege = "USE" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+	t.Cleanup(func() {
+		tearDownTestTableForOwner(t, tableName, ownerName)
+	})
+}
+
+func TestAcc_GrantObjectPrivilegesResource_GrantOption(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tableName := "test_table_" + randString
+	ownerName := "test_owner_" + randString
+	setupTestTableForOwner(t, tableName, ownerName)
+	config := func(withGrantOption bool) string {
+		return providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_object_privileges" "test_grant" {
+  principal   = oracle_user.test_user.username
+  owner       = "%s"
+  object      = "%s"
+  privileges  = [{ privilege = "SELECT", with_grant_option = %t }, { privilege = "UPDATE" }]
+  grants_mode = "enforce"
+}
+`, randString, ownerName, tableName, withGrantOption)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{
+						"privilege":         "SELECT",
+						"with_grant_option": "true",
+						"hierarchy_option":  "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{
+						"privilege":         "UPDATE",
+						"with_grant_option": "false",
+					}),
+				),
+			},
+			// Removing the grant option regrants the privilege without it
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_object_privileges.test_grant", "privileges.*", map[string]string{
+						"privilege":         "SELECT",
+						"with_grant_option": "false",
+					}),
+				),
+			},
+			{
+				ResourceName:      "oracle_grant_object_privileges.test_grant",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+		},
+	})
+	t.Cleanup(func() {
+		tearDownTestTableForOwner(t, tableName, ownerName)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// toGrantAttribute returns the schema of the computed to_grant attribute
+// shared by the grant resources. The kind describes what is granted, e.g.
+// "privileges" or "roles".
+func toGrantAttribute(kind string) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "The " + kind + " that applying the plan grants, as found when the plan was created. Empty once the grants are refreshed.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+}
+
+// toRevokeAttribute returns the schema of the computed to_revoke attribute
+// shared by the grant resources.
+func toRevokeAttribute(kind string) schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "The " + kind + " that applying the plan revokes, as found when the plan was created, e.g. grants that `enforce` mode removes. " +
+			"A warning is shown in the plan whenever this is not empty. Empty once the grants are refreshed.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+// noPendingGrants returns the to_grant or to_revoke value stored after the
+// grants are read from the database, when no change is pending.
+func noPendingGrants() types.Set {
+	return types.SetValueMust(types.StringType, []attr.Value{})
+}
+
+// grantPlanUnknown checks if the grants of a resource cannot be previewed
+// because the provider is not configured yet or the configuration has unknown
+// values.
+func grantPlanUnknown(req resource.ModifyPlanRequest, client *oracle.Client) bool {
+	return client == nil || !req.Config.Raw.IsFullyKnown()
+}
+
+// appliedGrants returns the to_grant or to_revoke value stored after apply.
+// Terraform requires a known planned value to be stored unchanged, so only a
+// value that was unknown at plan time is replaced by the empty value that
+// Read stores once the grants have converged.
+func appliedGrants(planned types.Set) types.Set {
+	if planned.IsUnknown() {
+		return noPendingGrants()
+	}
+	return planned
+}
+
+// setGrantPlan sets the to_grant and to_revoke attributes of a plan, or
+// leaves them unknown if plan is nil, and warns about grants that are revoked.
+func setGrantPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, principal string, plan *oracle.GrantPlan) {
+	if plan == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_grant"), types.SetUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_revoke"), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	// An empty preview is stored as the value Read stores, so that a
+	// converged resource shows no pending grants.
+	var diags diag.Diagnostics
+	toGrant, toRevoke := noPendingGrants(), noPendingGrants()
+	if len(plan.ToGrant) > 0 {
+		var d diag.Diagnostics
+		toGrant, d = types.SetValueFrom(ctx, types.StringType, plan.ToGrant)
+		diags.Append(d...)
+	}
+	if len(plan.ToRevoke) > 0 {
+		var d diag.Diagnostics
+		toRevoke, d = types.SetValueFrom(ctx, types.StringType, plan.ToRevoke)
+		diags.Append(d...)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_grant"), toGrant)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("to_revoke"), toRevoke)...)
+
+	if len(plan.ToRevoke) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("to_revoke"),
+			"Grants Will Be Revoked",
+			fmt.Sprintf("Applying this plan revokes the following grants from %s: %s.", principal, strings.Join(plan.ToRevoke, ", ")),
+		)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppliedGrants(t *testing.T) {
+	planned := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("CREATE SESSION")})
+
+	assert.Equal(t, noPendingGrants(), appliedGrants(types.SetUnknown(types.StringType)))
+	assert.Equal(t, planned, appliedGrants(planned))
+	assert.Equal(t, noPendingGrants(), appliedGrants(noPendingGrants()))
+}
@@ -7,11 +7,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,6 +27,9 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GrantRolesResource{}
 var _ resource.ResourceWithImportState = &GrantRolesResource{}
+var _ resource.ResourceWithValidateConfig = &GrantRolesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantRolesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantRolesResource{}
 
 func NewGrantRolesResource() resource.Resource {
 	return &GrantRolesResource{}
@@ -33,10 +42,18 @@ type GrantRolesResource struct {
 
 // GrantRolesResourceModel describes the resource data model.
 type GrantRolesResourceModel struct {
-	Principal  types.String `tfsdk:"principal"`
-	Roles      types.Set    `tfsdk:"roles"`
-	GrantsMode types.String `tfsdk:"grants_mode"`
-	ID         types.String `tfsdk:"id"`
+	Principal             types.String `tfsdk:"principal"`
+	Roles                 types.Set    `tfsdk:"roles"`
+	AdminRoles            types.Set    `tfsdk:"admin_roles"`
+	DefaultRoles          types.Set    `tfsdk:"default_roles"`
+	GrantsMode            types.String `tfsdk:"grants_mode"`
+	ContainerScope        types.String `tfsdk:"container_scope"`
+	Ignore                types.Set    `tfsdk:"ignore"`
+	AllowOracleMaintained types.Bool   `tfsdk:"allow_oracle_maintained"`
+	AllowPublic           types.Bool   `tfsdk:"allow_public"`
+	ToGrant               types.Set    `tfsdk:"to_grant"`
+	ToRevoke              types.Set    `tfsdk:"to_revoke"`
+	ID                    types.String `tfsdk:"id"`
 }
 
 func (r *GrantRolesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,21 +63,52 @@ func (r *GrantRolesResource) Metadata(ctx context.Context, req resource.Metadata
 func (r *GrantRolesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A resource to manage roles for a user or role.",
+		Version:             2,
 
 		Attributes: map[string]schema.Attribute{
 			"principal": schema.StringAttribute{
-				MarkdownDescription: "The user or role to whom the roles are granted.",
+				MarkdownDescription: "The user or role to whom the roles are granted. Changing this revokes the grants from the previous principal.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+				Validators: []validator.String{
+					publicPrincipalValidator{},
+				},
 			},
 			"roles": schema.SetAttribute{
 				MarkdownDescription: "The roles to grant to the principal. (This should be specified in lowercase. for example: `connect`, `resource`)",
 				ElementType:         types.StringType,
 				Required:            true,
 			},
+			"admin_roles": schema.SetAttribute{
+				MarkdownDescription: "The subset of `roles` to grant `WITH ADMIN OPTION`, allowing the principal to grant them to others. " +
+					"Removing a role from this set revokes and grants the role again without the admin option. If not specified, no role is granted with the admin option.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"default_roles": schema.SetAttribute{
+				MarkdownDescription: "The subset of `roles` that are enabled by default when the principal logs in (`ALTER USER ... DEFAULT ROLE`). " +
+					"Only valid when the principal is a user; the plan fails for roles and `PUBLIC`. Default roles of the user that are not managed by this resource are left unchanged. " +
+					"If not specified, the default roles are not managed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
 			"grants_mode": schema.StringAttribute{
 				MarkdownDescription: "The grants mode to use. If not specified, the default is `append`.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
 			},
+			"container_scope":         containerScopeAttribute("The container scope of the grant."),
+			"ignore":                  ignoreAttribute("roles"),
+			"allow_oracle_maintained": allowOracleMaintainedAttribute(),
+			"allow_public":            allowPublicAttribute(),
+			"to_grant":                toGrantAttribute("roles"),
+			"to_revoke":               toRevokeAttribute("roles"),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Grant identifier",
@@ -72,6 +120,128 @@ func (r *GrantRolesResource) Schema(ctx context.Context, req resource.SchemaRequ
 	}
 }
 
+func (r *GrantRolesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data GrantRolesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Roles.IsUnknown() {
+		return
+	}
+
+	// Default roles are set with ALTER USER, which PUBLIC does not support
+	if !data.DefaultRoles.IsNull() && !data.Principal.IsUnknown() && oracle.IsPublic(data.Principal.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_roles"),
+			"Invalid Attribute Configuration",
+			"default_roles can only be set when the principal is a user, not PUBLIC.",
+		)
+	}
+
+	var roles []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, true)...)
+
+	for _, attribute := range []string{"admin_roles", "default_roles"} {
+		var subset types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &subset)...)
+		if subset.IsNull() || subset.IsUnknown() {
+			continue
+		}
+
+		for _, element := range subset.Elements() {
+			role, ok := element.(types.String)
+			if !ok || role.IsUnknown() || oracle.ContainsFold(roles, role.ValueString()) {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Attribute Configuration",
+				fmt.Sprintf("%s contains %q, which is not in roles.", attribute, role.ValueString()),
+			)
+		}
+	}
+}
+
+// ModifyPlan previews the roles that are granted and revoked, so that
+// destructive changes are visible before apply.
+func (r *GrantRolesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to preview when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planResourceID(ctx, req, resp, "principal", "container_scope", "roles")
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var data GrantRolesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Default roles are set with ALTER USER, which fails for roles
+	var defaultRoles types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_roles"), &defaultRoles)...)
+	if !defaultRoles.IsNull() {
+		isRole, err := r.client.RoleExists(data.Principal.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+			return
+		}
+		if isRole {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_roles"),
+				"Invalid Attribute Configuration",
+				fmt.Sprintf("default_roles can only be set when the principal is a user, but %s is a role.", data.Principal.ValueString()),
+			)
+			return
+		}
+	}
+
+	var roles []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	managed, diags := managedRoles(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := oracle.GrantRole{
+		Principal:      data.Principal.ValueString(),
+		Roles:          roles,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+	resp.Diagnostics.Append(roleGrantOptions(ctx, data, &grant)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := r.client.PlanRoles(grant)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, data.Principal.ValueString(), &plan)
+}
+
+func (r *GrantRolesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantRolesResourceModel](priorSchemaUpgrader(resp.Schema)),
+		1: upgradeResourceID[GrantRolesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
 func (r *GrantRolesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -109,9 +279,14 @@ func (r *GrantRolesResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	grant := oracle.GrantRole{
-		Principal:  data.Principal.ValueString(),
-		Roles:      roles,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:      data.Principal.ValueString(),
+		Roles:          roles,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+	}
+	resp.Diagnostics.Append(roleGrantOptions(ctx, data, &grant)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	err := r.client.GrantRoles(grant)
@@ -120,10 +295,17 @@ func (r *GrantRolesResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	data.ID = data.Principal
+	data.ID = types.StringValue(rolesID(data.Principal.ValueString(), data.ContainerScope.ValueString(), roles))
 
 	tflog.Trace(ctx, "granted roles")
 
+	resp.Diagnostics.Append(r.readDefaultRoles(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -136,19 +318,74 @@ func (r *GrantRolesResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	roles, err := r.client.GetCurrentRoles(data.ID.ValueString())
+	parts, err := parseResourceID(data.ID.ValueString(), rolesKind, "principal:container_scope:roles", "roles")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Resource Identifier", err.Error())
+		return
+	}
+	principal := parts[0]
+
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	grants, err := r.client.GetCurrentRoleGrants(principal, data.ContainerScope.ValueString())
 	if err != nil {
 		// If the grant is not found, remove it from the state
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	data.Principal = data.ID
-	data.Roles, resp.Diagnostics = types.SetValueFrom(ctx, types.StringType, roles)
+	var priorRoles, ignore []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &priorRoles, false)...)
+	resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// In append mode only the roles of this resource are read, so that several
+	// resources can grant roles to one principal. Roles Oracle grants to
+	// PUBLIC by default are not drift either. All roles are read on import.
+	managedOnly := !data.Roles.IsNull() && (data.GrantsMode.ValueString() != "enforce" || oracle.IsPublic(principal))
+	roles, adminRoles, defaultRoles := []string{}, []string{}, []string{}
+	for _, grant := range grants {
+		if len(withoutIgnored([]string{grant.Role}, priorRoles, ignore)) == 0 {
+			continue
+		}
+		if managedOnly && !oracle.ContainsFold(priorRoles, grant.Role) {
+			continue
+		}
+		roles = append(roles, grant.Role)
+		if grant.AdminOption {
+			adminRoles = append(adminRoles, grant.Role)
+		}
+		if grant.Default {
+			defaultRoles = append(defaultRoles, grant.Role)
+		}
+	}
+
+	var diags diag.Diagnostics
+	if data.AllowOracleMaintained.IsNull() {
+		data.AllowOracleMaintained = types.BoolValue(false)
+	}
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(principal))
+	}
+	data.Principal = types.StringValue(principal)
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+	data.Roles, diags = types.SetValueFrom(ctx, types.StringType, roles)
+	resp.Diagnostics.Append(diags...)
+	data.AdminRoles, diags = types.SetValueFrom(ctx, types.StringType, adminRoles)
+	resp.Diagnostics.Append(diags...)
+	data.DefaultRoles, diags = types.SetValueFrom(ctx, types.StringType, defaultRoles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An import that adopts every role is identified by the roles it read
+	if priorRoles == nil {
+		data.ID = types.StringValue(rolesID(principal, data.ContainerScope.ValueString(), roles))
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -164,14 +401,23 @@ func (r *GrantRolesResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	var roles []string
 	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	managed, diags := managedRoles(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.GrantRole{
-		Principal:  data.Principal.ValueString(),
-		Roles:      roles,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:      data.Principal.ValueString(),
+		Roles:          roles,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	resp.Diagnostics.Append(roleGrantOptions(ctx, data, &grant)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	err := r.client.GrantRoles(grant)
@@ -180,6 +426,15 @@ func (r *GrantRolesResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	data.ID = types.StringValue(rolesID(data.Principal.ValueString(), data.ContainerScope.ValueString(), roles))
+
+	resp.Diagnostics.Append(r.readDefaultRoles(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -199,8 +454,9 @@ func (r *GrantRolesResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	grant := oracle.GrantRole{
-		Principal: data.Principal.ValueString(),
-		Roles:     roles,
+		Principal:      data.Principal.ValueString(),
+		Roles:          roles,
+		ContainerScope: data.ContainerScope.ValueString(),
 	}
 
 	err := r.client.RevokeRoles(grant)
@@ -211,5 +467,95 @@ func (r *GrantRolesResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (r *GrantRolesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// The canonical identifier may be followed by the roles to adopt
+	parts, err := parseResourceID(req.ID, rolesKind, "principal:container_scope:roles", "roles")
+	if err != nil {
+		parts, err = parseImportID(req.ID, rolesKind, "principal:container_scope", "principal", "container_scope")
+		parts = append(parts, "")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	containerScope, roles := importedContainerScope(parts[1]), splitIDList(parts[2])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), rolesID(parts[0], containerScope, roles))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), parts[0])...)
+	if len(roles) > 0 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("roles"), roles)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grants_mode"), "append")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_scope"), containerScope)...)
+}
+
+// roleGrantOptions copies the admin and default roles and the enforce mode
+// settings of the model to grant.
+// Default roles are only managed when they are set in the configuration.
+func roleGrantOptions(ctx context.Context, data GrantRolesResourceModel, grant *oracle.GrantRole) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(data.AdminRoles.ElementsAs(ctx, &grant.AdminRoles, false)...)
+	diags.Append(data.Ignore.ElementsAs(ctx, &grant.Ignore, false)...)
+	grant.AllowOracleMaintained = data.AllowOracleMaintained.ValueBool()
+	if !data.DefaultRoles.IsNull() && !data.DefaultRoles.IsUnknown() {
+		grant.DefaultRoles = []string{}
+		diags.Append(data.DefaultRoles.ElementsAs(ctx, &grant.DefaultRoles, false)...)
+	}
+	return diags
+}
+
+// managedRoles returns the roles stored in the prior state of a role grant,
+// which are the only roles that enforce mode may revoke from PUBLIC.
+func managedRoles(ctx context.Context, state tfsdk.State) ([]string, diag.Diagnostics) {
+	var roles []string
+	if state.Raw.IsNull() {
+		return roles, nil
+	}
+
+	diags := state.GetAttribute(ctx, path.Root("roles"), &roles)
+	return roles, diags
+}
+
+// readDefaultRoles populates default_roles from the database when it is not
+// set in the configuration.
+func (r *GrantRolesResource) readDefaultRoles(ctx context.Context, data *GrantRolesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !data.DefaultRoles.IsUnknown() {
+		return diags
+	}
+
+	grants, err := r.client.GetCurrentRoleGrants(data.Principal.ValueString(), data.ContainerScope.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read roles, got error: %s", err))
+		return diags
+	}
+
+	// Default roles granted by other resources are not read
+	var roles []string
+	diags.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	defaultRoles := []string{}
+	for _, grant := range grants {
+		if grant.Default && oracle.ContainsFold(roles, grant.Role) {
+			defaultRoles = append(defaultRoles, grant.Role)
+		}
+	}
+	defaultRolesValue, setDiags := types.SetValueFrom(ctx, types.StringType, defaultRoles)
+	diags.Append(setDiags...)
+	data.DefaultRoles = defaultRolesValue
+	return diags
+}
+
+// rolesKind is the kind of the identifier of role grants.
+const rolesKind = "roles"
+
+// rolesID returns the identifier of role grants, e.g.
+// "roles:app_user:current:connect,resource". The sorted roles are part of the
+// identifier so that several resources can grant roles to one principal.
+func rolesID(principal, containerScope string, roles []string) string {
+	return formatResourceID(rolesKind, principal, containerScope, joinSortedIDList(roles))
+}
+
+func (data GrantRolesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	var roles []string
+	diags := data.Roles.ElementsAs(ctx, &roles, false)
+	return rolesID(data.Principal.ValueString(), containerScopeValue(data.ContainerScope).ValueString(), roles), diags
 }
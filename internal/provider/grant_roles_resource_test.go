@@ -5,11 +5,13 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 const (
@@ -30,6 +32,18 @@ func TestAccGrantRolesResource(t *testing.T) {
 					resource.TestCheckResourceAttr(grantRolesResourceName, "roles.0", "test_role_1"),
 				),
 			},
+			{
+				ResourceName:      grantRolesResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:    grantRolesResourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
 			{
 				Config: testAccGrantRolesResource("test_user_roles", "test_role_2"),
 				Check: resource.ComposeTestCheckFunc(
@@ -42,6 +56,153 @@ func TestAccGrantRolesResource(t *testing.T) {
 	})
 }
 
+func TestAccGrantRolesResource_AdminAndDefaultRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"oracle": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrantRolesResourceWithOptions("test_user_admin_roles", "test_role_admin", `
+  admin_roles   = [oracle_role.test.name]
+  default_roles = []
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(grantRolesResourceName, "admin_roles.#", "1"),
+					resource.TestCheckResourceAttr(grantRolesResourceName, "admin_roles.0", "test_role_admin"),
+					resource.TestCheckResourceAttr(grantRolesResourceName, "default_roles.#", "0"),
+				),
+			},
+			{
+				ResourceName:      grantRolesResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			// Remove the admin option and enable the role by default
+			{
+				Config: testAccGrantRolesResourceWithOptions("test_user_admin_roles", "test_role_admin", `
+  default_roles = [oracle_role.test.name]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(grantRolesResourceName, "admin_roles.#", "0"),
+					resource.TestCheckResourceAttr(grantRolesResourceName, "default_roles.#", "1"),
+				),
+			},
+			{
+				Config: testAccGrantRolesResourceWithOptions("test_user_admin_roles", "test_role_admin", `
+  admin_roles = ["dba"]
+`),
+				ExpectError: regexp.MustCompile(`which is not in roles`),
+			},
+		},
+	})
+}
+
+func TestAccGrantRolesResource_MultipleResources(t *testing.T) {
+	config := `
+resource "oracle_user" "test" {
+  username = "test_user_multi_roles"
+  password = "MyPassword123"
+}
+
+resource "oracle_role" "app" {
+  name = "test_role_multi_app"
+}
+
+resource "oracle_role" "report" {
+  name = "test_role_multi_report"
+}
+
+resource "oracle_grant_roles" "app" {
+  principal = oracle_user.test.username
+  roles     = [oracle_role.app.name]
+}
+
+resource "oracle_grant_roles" "report" {
+  principal = oracle_user.test.username
+  roles     = [oracle_role.report.name]
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"oracle": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_roles.app", "id", "roles:test_user_multi_roles:current:test_role_multi_app"),
+					resource.TestCheckResourceAttr("oracle_grant_roles.report", "id", "roles:test_user_multi_roles:current:test_role_multi_report"),
+					resource.TestCheckResourceAttrPair("oracle_grant_roles.app", "principal", "oracle_grant_roles.report", "principal"),
+					func(s *terraform.State) error {
+						app := s.RootModule().Resources["oracle_grant_roles.app"].Primary.ID
+						report := s.RootModule().Resources["oracle_grant_roles.report"].Primary.ID
+						if app == report {
+							return fmt.Errorf("expected different identifiers for the role grants, got %q for both", app)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("oracle_grant_roles.app", "roles.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_roles.app", "roles.0", "test_role_multi_app"),
+					resource.TestCheckResourceAttr("oracle_grant_roles.report", "roles.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_roles.report", "roles.0", "test_role_multi_report"),
+				),
+			},
+			// Neither resource reads the roles of the other one as drift
+			{
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccGrantRolesResource_DefaultRolesForRoleOrPublic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"oracle": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "oracle_grant_roles" "test" {
+  principal     = "public"
+  roles         = ["connect"]
+  default_roles = ["connect"]
+  allow_public  = true
+}
+`,
+				ExpectError: regexp.MustCompile(`not PUBLIC`),
+			},
+			// The principal must exist to be recognized as a role
+			{
+				Config: `
+resource "oracle_role" "test" {
+  name = "test_role_default_roles"
+}
+`,
+			},
+			{
+				Config: `
+resource "oracle_role" "test" {
+  name = "test_role_default_roles"
+}
+
+resource "oracle_grant_roles" "test" {
+  principal     = oracle_role.test.name
+  roles         = ["connect"]
+  default_roles = ["connect"]
+}
+`,
+				ExpectError: regexp.MustCompile(`is a role`),
+			},
+		},
+	})
+}
+
 func testAccGrantRolesResource(user, role string) string {
 	return fmt.Sprintf(`
 resource "oracle_user" "test" {
@@ -59,3 +220,21 @@ resource "oracle_grant_roles" "test" {
 }
 `, user, role)
 }
+
+func testAccGrantRolesResourceWithOptions(user, role, options string) string {
+	return fmt.Sprintf(`
+resource "oracle_user" "test" {
+  username = %[1]q
+  password = "MyPassword123"
+}
+
+resource "oracle_role" "test" {
+  name = %[2]q
+}
+
+resource "oracle_grant_roles" "test" {
+  principal = oracle_user.test.username
+  roles     = [oracle_role.test.name]
+%[3]s}
+`, user, role, options)
+}
@@ -0,0 +1,376 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GrantSchemaPrivilegesResource{}
+var _ resource.ResourceWithImportState = &GrantSchemaPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantSchemaPrivilegesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantSchemaPrivilegesResource{}
+
+func NewGrantSchemaPrivilegesResource() resource.Resource {
+	return &GrantSchemaPrivilegesResource{}
+}
+
+// GrantSchemaPrivilegesResource defines the resource implementation.
+type GrantSchemaPrivilegesResource struct {
+	client *oracle.Client
+}
+
+// GrantSchemaPrivilegesResourceModel describes the resource data model.
+type GrantSchemaPrivilegesResourceModel struct {
+	Principal      types.String `tfsdk:"principal"`
+	Schema         types.String `tfsdk:"schema"`
+	Privileges     types.Set    `tfsdk:"privileges"`
+	GrantsMode     types.String `tfsdk:"grants_mode"`
+	ContainerScope types.String `tfsdk:"container_scope"`
+	AllowPublic    types.Bool   `tfsdk:"allow_public"`
+	ToGrant        types.Set    `tfsdk:"to_grant"`
+	ToRevoke       types.Set    `tfsdk:"to_revoke"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (r *GrantSchemaPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant_schema_privileges"
+}
+
+func (r *GrantSchemaPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A resource to manage schema-level privileges for a user or role. Schema privileges apply to all current and future objects of a schema and require Oracle 23ai or later.",
+		Version:             2,
+
+		Attributes: map[string]schema.Attribute{
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "The user or role to whom the privileges are granted. Changing this revokes the grants from the previous principal.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+				Validators: []validator.String{
+					publicPrincipalValidator{},
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "The schema on which the privileges are granted. Changing this revokes the grants on the previous schema.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+			},
+			"privileges": systemPrivilegeOptions.attribute("The privileges to grant on the schema, e.g. `{ privilege = \"SELECT ANY TABLE\" }` or `{ privilege = \"EXECUTE ANY PROCEDURE\" }`."),
+			"grants_mode": schema.StringAttribute{
+				MarkdownDescription: "The grants mode to use. If not specified, the default is `append`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
+			},
+			"container_scope": containerScopeAttribute("The container scope of the grant."),
+			"allow_public":    allowPublicAttribute(),
+			"to_grant":        toGrantAttribute("privileges"),
+			"to_revoke":       toRevokeAttribute("privileges"),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Grant identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan checks that the database supports schema privileges and previews
+// the privileges that are granted and revoked.
+func (r *GrantSchemaPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if r.client != nil {
+		version, err := r.client.DatabaseVersion()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database version, got error: %s", err))
+			return
+		}
+
+		if !version.AtLeast(23, 0) {
+			resp.Diagnostics.AddError(
+				"Unsupported Resource",
+				fmt.Sprintf("Schema privileges require Oracle 23ai or later, the database version is %s.", version),
+			)
+			return
+		}
+	}
+
+	planResourceID(ctx, req, resp, "principal", "schema", "container_scope")
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var data GrantSchemaPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := systemPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := r.client.PlanSchemaPrivileges(oracle.SchemaPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Schema:         data.Schema.ValueString(),
+		Privileges:     privileges,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schema privileges, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, data.Principal.ValueString(), &plan)
+}
+
+func (r *GrantSchemaPrivilegesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantSchemaPrivilegesResourceModel](systemPrivilegeOptions.upgrader(resp.Schema)),
+		1: upgradeResourceID[GrantSchemaPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
+func (r *GrantSchemaPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GrantSchemaPrivilegesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GrantSchemaPrivilegesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := oracle.SchemaPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Schema:         data.Schema.ValueString(),
+		Privileges:     privileges,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+	}
+
+	err := r.client.GrantSchemaPrivileges(grant)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant schema privileges, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(schemaPrivilegesID(data.Principal.ValueString(), data.Schema.ValueString(), data.ContainerScope.ValueString()))
+
+	tflog.Trace(ctx, "granted schema privileges")
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantSchemaPrivilegesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GrantSchemaPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts, err := parseResourceID(data.ID.ValueString(), schemaPrivilegesKind, "principal:schema:container_scope")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Resource Identifier", err.Error())
+		return
+	}
+	principal := parts[0]
+	schemaName := parts[1]
+
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	privileges, err := r.client.GetCurrentSchemaPrivileges(principal, schemaName, data.ContainerScope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schema privileges, got error: %s", err))
+		return
+	}
+
+	priorPrivileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(principal))
+	}
+	privileges = withoutUnmanagedPublic(principal, privileges, priorPrivileges, data.Privileges.IsNull())
+	data.Principal = types.StringValue(principal)
+	data.Schema = types.StringValue(schemaName)
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+
+	data.Privileges, diags = systemPrivilegeOptions.value(ctx, privileges, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantSchemaPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GrantSchemaPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	managed, diags := systemPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := oracle.SchemaPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Schema:         data.Schema.ValueString(),
+		Privileges:     privileges,
+		GrantsMode:     data.GrantsMode.ValueString(),
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	err := r.client.GrantSchemaPrivileges(grant)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schema privileges, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(schemaPrivilegesID(data.Principal.ValueString(), data.Schema.ValueString(), data.ContainerScope.ValueString()))
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantSchemaPrivilegesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GrantSchemaPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := systemPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant := oracle.SchemaPrivilege{
+		Principal:      data.Principal.ValueString(),
+		Schema:         data.Schema.ValueString(),
+		Privileges:     []oracle.Privilege{},
+		GrantsMode:     "enforce",
+		ContainerScope: data.ContainerScope.ValueString(),
+		Managed:        managed,
+	}
+
+	err := r.client.GrantSchemaPrivileges(grant)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke schema privileges, got error: %s", err))
+		return
+	}
+}
+
+func (r *GrantSchemaPrivilegesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, schemaPrivilegesKind, "principal:schema:container_scope", "principal:schema", "container_scope")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	containerScope := importedContainerScope(parts[2])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), schemaPrivilegesID(parts[0], parts[1], containerScope))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grants_mode"), "append")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_scope"), containerScope)...)
+}
+
+// schemaPrivilegesKind is the kind of the identifier of schema privilege grants.
+const schemaPrivilegesKind = "schema_privileges"
+
+// schemaPrivilegesID returns the identifier of schema privilege grants, e.g.
+// "schema_privileges:app_user:hr:current".
+func schemaPrivilegesID(principal, schemaName, containerScope string) string {
+	return formatResourceID(schemaPrivilegesKind, principal, schemaName, containerScope)
+}
+
+func (data GrantSchemaPrivilegesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	return schemaPrivilegesID(data.Principal.ValueString(), data.Schema.ValueString(), containerScopeValue(data.ContainerScope).ValueString()), nil
+}
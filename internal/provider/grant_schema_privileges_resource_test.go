@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_GrantSchemaPrivilegesResource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipUnlessVersion(t, 23)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_user" "test_owner" {
+  username = "testowner_%s"
+  password = "password"
+}
+
+resource "oracle_grant_schema_privileges" "test_grant" {
+  principal  = oracle_user.test_user.username
+  schema     = oracle_user.test_owner.username
+  privileges = [{ privilege = "SELECT ANY TABLE" }]
+}
+`, randString, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_schema_privileges.test_grant", "principal", fmt.Sprintf("testuser_%s", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_schema_privileges.test_grant", "schema", fmt.Sprintf("testowner_%s", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_schema_privileges.test_grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_schema_privileges.test_grant", "privileges.*", map[string]string{"privilege": "SELECT ANY TABLE"}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "oracle_grant_schema_privileges.test_grant",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:  "oracle_grant_schema_privileges.test_grant",
+				ImportState:   true,
+				ImportStateId: "principal_only",
+				ExpectError:   regexp.MustCompile(`Unexpected Import Identifier`),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_user" "test_owner" {
+  username = "testowner_%s"
+  password = "password"
+}
+
+resource "oracle_grant_schema_privileges" "test_grant" {
+  principal   = oracle_user.test_user.username
+  schema      = oracle_user.test_owner.username
+  privileges  = [{ privilege = "SELECT ANY TABLE" }, { privilege = "EXECUTE ANY PROCEDURE" }]
+  grants_mode = "enforce"
+}
+`, randString, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_schema_privileges.test_grant", "privileges.#", "2"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckNoGrants("dba_schema_privs", fmt.Sprintf("testuser_%s", randString)),
+	})
+}
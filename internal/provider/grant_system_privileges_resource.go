@@ -6,11 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -21,6 +24,8 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GrantSystemPrivilegesResource{}
 var _ resource.ResourceWithImportState = &GrantSystemPrivilegesResource{}
+var _ resource.ResourceWithUpgradeState = &GrantSystemPrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &GrantSystemPrivilegesResource{}
 
 func NewGrantSystemPrivilegesResource() resource.Resource {
 	return &GrantSystemPrivilegesResource{}
@@ -33,10 +38,17 @@ type GrantSystemPrivilegesResource struct {
 
 // GrantSystemPrivilegesResourceModel describes the resource data model.
 type GrantSystemPrivilegesResourceModel struct {
-	Principal  types.String `tfsdk:"principal"`
-	Privileges types.Set    `tfsdk:"privileges"`
-	GrantsMode types.String `tfsdk:"grants_mode"`
-	ID         types.String `tfsdk:"id"`
+	Principal             types.String `tfsdk:"principal"`
+	Principals            types.Set    `tfsdk:"principals"`
+	Privileges            types.Set    `tfsdk:"privileges"`
+	GrantsMode            types.String `tfsdk:"grants_mode"`
+	ContainerScope        types.String `tfsdk:"container_scope"`
+	Ignore                types.Set    `tfsdk:"ignore"`
+	AllowOracleMaintained types.Bool   `tfsdk:"allow_oracle_maintained"`
+	AllowPublic           types.Bool   `tfsdk:"allow_public"`
+	ToGrant               types.Set    `tfsdk:"to_grant"`
+	ToRevoke              types.Set    `tfsdk:"to_revoke"`
+	ID                    types.String `tfsdk:"id"`
 }
 
 func (r *GrantSystemPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,21 +58,24 @@ func (r *GrantSystemPrivilegesResource) Metadata(ctx context.Context, req resour
 func (r *GrantSystemPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A resource to manage system privileges for a user or role.",
+		Version:             3,
 
 		Attributes: map[string]schema.Attribute{
-			"principal": schema.StringAttribute{
-				MarkdownDescription: "The user or role to whom the privileges are granted.",
-				Required:            true,
-			},
-			"privileges": schema.SetAttribute{
-				MarkdownDescription: "The system privileges to grant to the principal. (This should be specified in uppercase. for example: `CREATE SESSION`)",
-				ElementType:         types.StringType,
-				Required:            true,
-			},
+			"principal":  principalAttribute(),
+			"principals": principalsAttribute(),
+			"privileges": systemPrivilegeOptions.attribute("The system privileges to grant to the principal, e.g. `{ privilege = \"CREATE SESSION\" }`."),
 			"grants_mode": schema.StringAttribute{
 				MarkdownDescription: "The grants mode to use. If not specified, the default is `append`.",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("append"),
 			},
+			"container_scope":         containerScopeAttribute("The container scope of the grant."),
+			"ignore":                  ignoreAttribute("privileges"),
+			"allow_oracle_maintained": allowOracleMaintainedAttribute(),
+			"allow_public":            allowPublicAttribute(),
+			"to_grant":                toGrantAttribute("privileges"),
+			"to_revoke":               toRevokeAttribute("privileges"),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Grant identifier",
@@ -72,6 +87,75 @@ func (r *GrantSystemPrivilegesResource) Schema(ctx context.Context, req resource
 	}
 }
 
+// ModifyPlan checks the privilege names against the privilege catalog of the
+// database and previews the privileges that are granted and revoked, so that
+// typos and destructive changes are visible before apply.
+func (r *GrantSystemPrivilegesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to preview when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planResourceID(ctx, req, resp, "principal", "principals", "container_scope", "privileges")
+	if grantPlanUnknown(req, r.client) {
+		setGrantPlan(ctx, req, resp, "", nil)
+		return
+	}
+
+	var data GrantSystemPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ignore []string
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
+	managed, diags := systemPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(systemPrivilegeOptions.checkCatalog(r.client, privileges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := planGrants(data.Principal, principals, func(principal string) (oracle.GrantPlan, error) {
+		return r.client.PlanSystemPrivileges(oracle.Grant{
+			Principal:      principal,
+			Privileges:     privileges,
+			GrantsMode:     data.GrantsMode.ValueString(),
+			ContainerScope: data.ContainerScope.ValueString(),
+			Ignore:         ignore,
+			Managed:        managed,
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read system privileges, got error: %s", err))
+		return
+	}
+
+	setGrantPlan(ctx, req, resp, strings.Join(principals, ", "), &plan)
+}
+
+func (r *GrantSystemPrivilegesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[int64]resource.StateUpgrader{
+		0: upgradeResourceID[GrantSystemPrivilegesResourceModel](systemPrivilegeOptions.upgrader(resp.Schema)),
+		1: upgradeResourceID[GrantSystemPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+		2: upgradeResourceID[GrantSystemPrivilegesResourceModel](priorSchemaUpgrader(resp.Schema)),
+	}
+}
+
 func (r *GrantSystemPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -102,28 +186,37 @@ func (r *GrantSystemPrivilegesResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	var ignore []string
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.Grant{
-		Principal:  data.Principal.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Privileges:            privileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		Ignore:                ignore,
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
 	}
 
-	err := r.client.GrantSystemPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant system privileges, got error: %s", err))
 		return
 	}
 
-	data.ID = data.Principal
+	data.ID = types.StringValue(systemPrivilegesID(principals, data.ContainerScope.ValueString(), privileges))
 
 	tflog.Trace(ctx, "granted system privileges")
 
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -136,50 +229,122 @@ func (r *GrantSystemPrivilegesResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	privileges, err := r.client.GetCurrentSystemPrivileges(data.ID.ValueString())
+	var ignore []string
+	priorPrivileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts, err := parseResourceID(data.ID.ValueString(), systemPrivilegesKind, "principals:container_scope:privileges", "privileges")
 	if err != nil {
-		// If the grant is not found, remove it from the state
-		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddError("Invalid Resource Identifier", err.Error())
 		return
 	}
+	principals := splitIDList(parts[0])
+
+	data.ContainerScope = containerScopeValue(data.ContainerScope)
+	imported := data.Privileges.IsNull()
+	managedOnly := !imported && data.GrantsMode.ValueString() != "enforce"
+	var current [][]oracle.Privilege
+	for _, principal := range principals {
+		privileges, err := r.client.GetCurrentSystemPrivileges(principal, data.ContainerScope.ValueString())
+		if err != nil {
+			// If the grant is not found, remove it from the state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		privileges = withoutIgnoredPrivileges(privileges, priorPrivileges, ignore)
+		// In append mode only the privileges of this resource are read, so that
+		// several resources can grant system privileges to one principal
+		if managedOnly {
+			privileges = withoutUnmanaged(privileges, priorPrivileges)
+		}
+		current = append(current, withoutUnmanagedPublic(principal, privileges, priorPrivileges, imported))
+	}
 
-	data.Principal = data.ID
-	data.Privileges, resp.Diagnostics = types.SetValueFrom(ctx, types.StringType, privileges)
+	if data.AllowOracleMaintained.IsNull() {
+		data.AllowOracleMaintained = types.BoolValue(false)
+	}
+	if data.AllowPublic.IsNull() {
+		data.AllowPublic = types.BoolValue(oracle.IsPublic(principals[0]))
+	}
+	if data.Principals.IsNull() {
+		data.Principal = types.StringValue(principals[0])
+	}
+	data.ToGrant = noPendingGrants()
+	data.ToRevoke = noPendingGrants()
+	data.Privileges, diags = systemPrivilegeOptions.value(ctx, commonPrivileges(current, priorPrivileges), data.Privileges)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// An import that adopts every privilege is identified by the privileges it read
+	if imported {
+		data.ID = types.StringValue(systemPrivilegesID(principals, data.ContainerScope.ValueString(), commonPrivileges(current, priorPrivileges)))
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *GrantSystemPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data GrantSystemPrivilegesResourceModel
+	var data, state GrantSystemPrivilegesResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var privileges []string
-	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, false)...)
+	var ignore []string
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
+	managed, diags := systemPrivilegeOptions.managedPrivileges(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrincipals, diags := grantPrincipals(ctx, state.Principal, state.Principals)
+	resp.Diagnostics.Append(diags...)
+	statePrivileges, diags := systemPrivilegeOptions.privileges(ctx, state.Privileges)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	grant := oracle.Grant{
-		Principal:  data.Principal.ValueString(),
-		Privileges: privileges,
-		GrantsMode: data.GrantsMode.ValueString(),
+		Principal:             data.Principal.ValueString(),
+		Privileges:            privileges,
+		GrantsMode:            data.GrantsMode.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		Ignore:                ignore,
+		AllowOracleMaintained: data.AllowOracleMaintained.ValueBool(),
+		Managed:               managed,
 	}
 
-	err := r.client.GrantSystemPrivileges(grant)
+	err := r.grant(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update system privileges, got error: %s", err))
 		return
 	}
 
+	// Revoke the grants of the principals that were removed
+	if removed := difference(statePrincipals, principals); len(removed) > 0 {
+		grant.Privileges = statePrivileges
+		if err := r.client.RevokeSystemPrivilegesFromPrincipals(grant, removed); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke system privileges, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(systemPrivilegesID(principals, data.ContainerScope.ValueString(), privileges))
+
+	data.ToGrant = appliedGrants(data.ToGrant)
+	data.ToRevoke = appliedGrants(data.ToRevoke)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -192,13 +357,22 @@ func (r *GrantSystemPrivilegesResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	privileges, diags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	resp.Diagnostics.Append(diags...)
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke only the privileges in state, so that the other system
+	// privileges of the principals are left alone
 	grant := oracle.Grant{
-		Principal:  data.Principal.ValueString(),
-		Privileges: []string{},
-		GrantsMode: "enforce",
+		Privileges:     privileges,
+		ContainerScope: data.ContainerScope.ValueString(),
 	}
 
-	err := r.client.GrantSystemPrivileges(grant)
+	err := r.client.RevokeSystemPrivilegesFromPrincipals(grant, principals)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke system privileges, got error: %s", err))
 		return
@@ -206,5 +380,59 @@ func (r *GrantSystemPrivilegesResource) Delete(ctx context.Context, req resource
 }
 
 func (r *GrantSystemPrivilegesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// The canonical identifier may be followed by the privileges to adopt
+	parts, err := parseResourceID(req.ID, systemPrivilegesKind, "principals:container_scope:privileges", "privileges")
+	if err != nil {
+		parts, err = parseImportID(req.ID, systemPrivilegesKind, "principals:container_scope", "principals", "container_scope")
+		parts = append(parts, "")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	principals, containerScope := splitIDList(parts[0]), importedContainerScope(parts[1])
+	privileges := []oracle.Privilege{}
+	for _, name := range splitIDList(parts[2]) {
+		privileges = append(privileges, oracle.Privilege{Name: name})
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), systemPrivilegesID(principals, containerScope, privileges))...)
+	resp.Diagnostics.Append(importPrincipals(ctx, &resp.State, principals)...)
+	if len(privileges) > 0 {
+		privilegesValue, diags := systemPrivilegeOptions.value(ctx, privileges, types.SetNull(systemPrivilegeOptions.objectType()))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("privileges"), privilegesValue)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("grants_mode"), "append")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_scope"), containerScope)...)
+}
+
+// grant grants system privileges to the principal, or to each of several principals.
+func (r *GrantSystemPrivilegesResource) grant(grant oracle.Grant, principals []string) error {
+	if grant.Principal != "" {
+		return r.client.GrantSystemPrivileges(grant)
+	}
+	return r.client.GrantSystemPrivilegesToPrincipals(grant, principals)
+}
+
+// systemPrivilegesKind is the kind of the identifier of system privilege grants.
+const systemPrivilegesKind = "system_privileges"
+
+// systemPrivilegesID returns the identifier of system privilege grants, e.g.
+// "system_privileges:app_reader,app_writer:current:CREATE SESSION". The sorted
+// privilege names are part of the identifier so that several resources can
+// grant system privileges to the same principals.
+func systemPrivilegesID(principals []string, containerScope string, privileges []oracle.Privilege) string {
+	names := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		names[i] = privilege.Name
+	}
+	return formatResourceID(systemPrivilegesKind, joinIDList(principals), containerScope, joinSortedIDList(names))
+}
+
+func (data GrantSystemPrivilegesResourceModel) resourceID(ctx context.Context) (string, diag.Diagnostics) {
+	principals, diags := grantPrincipals(ctx, data.Principal, data.Principals)
+	privileges, privilegeDiags := systemPrivilegeOptions.privileges(ctx, data.Privileges)
+	diags.Append(privilegeDiags...)
+	return systemPrivilegesID(principals, containerScopeValue(data.ContainerScope).ValueString(), privileges), diags
 }
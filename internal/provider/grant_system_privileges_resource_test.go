@@ -5,10 +5,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAcc_GrantSystemPrivilegesResource(t *testing.T) {
@@ -27,13 +32,13 @@ resource "oracle_user" "test_user" {
 
 resource "oracle_grant_system_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
-  privileges = toset(["CREATE SESSION"])
+  privileges = [{ privilege = "CREATE SESSION" }]
 }
 `, randString),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principal", fmt.Sprintf("testuser_%s", randString)),
 					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
-					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.0", "CREATE SESSION"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_system_privileges.test_grant", "privileges.*", map[string]string{"privilege": "CREATE SESSION"}),
 				),
 			},
 			// ImportState testing
@@ -41,6 +46,13 @@ resource "oracle_grant_system_privileges" "test_grant" {
 				ResourceName:      "oracle_grant_system_privileges.test_grant",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The preview of the last apply is reset when the grants are read
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			{
+				ResourceName:    "oracle_grant_system_privileges.test_grant",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
 			},
 			// Update and Read testing
 			{
@@ -52,16 +64,353 @@ resource "oracle_user" "test_user" {
 
 resource "oracle_grant_system_privileges" "test_grant" {
   principal  = oracle_user.test_user.username
-  privileges = ["CREATE SESSION", "CREATE TABLE"]
+  privileges = [{ privilege = "CREATE SESSION" }, { privilege = "CREATE TABLE" }]
 }
 `, randString),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "2"),
-					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.0", "CREATE SESSION"),
-					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.1", "CREATE TABLE"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_system_privileges.test_grant", "privileges.*", map[string]string{"privilege": "CREATE SESSION"}),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_system_privileges.test_grant", "privileges.*", map[string]string{"privilege": "CREATE TABLE"}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAcc_GrantSystemPrivilegesResource_MultipleResources(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	config := providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_grant_system_privileges" "session" {
+  principal  = oracle_user.test_user.username
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+
+resource "oracle_grant_system_privileges" "table" {
+  principal  = oracle_user.test_user.username
+  privileges = [{ privilege = "CREATE TABLE" }]
+}
+`, randString)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.session", "id", fmt.Sprintf("system_privileges:testuser_%s:current:CREATE SESSION", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.table", "id", fmt.Sprintf("system_privileges:testuser_%s:current:CREATE TABLE", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.session", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.table", "privileges.#", "1"),
+				),
+			},
+			// Neither resource reads the privileges of the other one as drift
+			{
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_ReplacePrincipal(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	config := func(principal string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "oracle_user" "first" {
+  username = "testuser_a_%[1]s"
+  password = "password"
+}
+
+resource "oracle_user" "second" {
+  username = "testuser_b_%[1]s"
+  password = "password"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal  = oracle_user.%[2]s.username
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+`, randString, principal)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principal", fmt.Sprintf("testuser_a_%s", randString)),
+					testAccCheckNoGrants("dba_sys_privs", fmt.Sprintf("testuser_b_%s", randString)),
+				),
+			},
+			// Changing the principal replaces the grant and revokes it from the previous principal
+			{
+				Config: config("second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oracle_grant_system_privileges.test_grant", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principal", fmt.Sprintf("testuser_b_%s", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
+					testAccCheckNoGrants("dba_sys_privs", fmt.Sprintf("testuser_a_%s", randString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_EnforceIgnore(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Enforce mode leaves privileges matching an ignore pattern alone
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+# A privilege granted outside of Terraform
+resource "oracle_sql" "other_team" {
+  sql = "GRANT CREATE VIEW TO ${oracle_user.test_user.username}"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal   = oracle_user.test_user.username
+  privileges  = [{ privilege = "CREATE SESSION" }]
+  grants_mode = "enforce"
+  ignore      = toset(["create view"])
+
+  depends_on = [oracle_sql.other_team]
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("oracle_grant_system_privileges.test_grant", "privileges.*", map[string]string{"privilege": "CREATE SESSION"}),
+					testAccCheckSystemPrivilege(fmt.Sprintf("testuser_%s", randString), "CREATE VIEW"),
+				),
+			},
+			// Enforce mode refuses to revoke privileges of Oracle-maintained principals
+			{
+				Config: providerConfig + `
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal   = "select_catalog_role"
+  privileges  = [{ privilege = "CREATE SESSION" }]
+  grants_mode = "enforce"
+}
+`,
+				ExpectError: regexp.MustCompile(`Oracle-maintained`),
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_PlanPreview(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	username := fmt.Sprintf("testuser_%s", randString)
+	config := providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "%s"
+  password = "password"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal   = oracle_user.test_user.username
+  privileges  = [{ privilege = "CREATE SESSION" }]
+  grants_mode = "enforce"
+}
+`, username)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_grant"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("CREATE SESSION"),
+						})),
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_revoke"), knownvalue.SetSizeExact(0)),
+					},
+				},
+			},
+			// A privilege granted outside of Terraform is previewed as revoked
+			{
+				PreConfig: func() {
+					db, err := getTestDB()
+					if err != nil {
+						t.Fatalf("Failed to connect to the database: %v", err)
+					}
+					defer db.Close()
+
+					if _, err := db.Exec("GRANT CREATE VIEW TO " + username); err != nil {
+						t.Fatalf("Failed to grant privilege: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oracle_grant_system_privileges.test_grant", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_grant"), knownvalue.SetSizeExact(0)),
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_revoke"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("CREATE VIEW"),
+						})),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
+					testAccCheckNoSystemPrivilege(username, "CREATE VIEW"),
+				),
+			},
+			// Once the grants have converged, the previous preview is not kept
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_grant"), knownvalue.SetSizeExact(0)),
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_revoke"), knownvalue.SetSizeExact(0)),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_UnknownPrivilege(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Typos fail at plan time with a suggestion
+			{
+				Config: providerConfig + `
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal  = "testuser"
+  privileges = [{ privilege = "CREAT SESSION" }]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`did you mean "CREATE SESSION"\?`),
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_Public(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Grants to PUBLIC require an explicit opt-in
+			{
+				Config: providerConfig + `
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal  = "PUBLIC"
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Set allow_public = true to confirm`),
+			},
+			// Enforce mode leaves the grants Oracle makes to PUBLIC alone
+			{
+				Config: providerConfig + `
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal    = "PUBLIC"
+  privileges   = [{ privilege = "CREATE SESSION" }]
+  grants_mode  = "enforce"
+  allow_public = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principal", "PUBLIC"),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "to_revoke.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_GrantSystemPrivilegesResource_Principals(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Grant to several principals at once
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "reader" {
+  name = "reader_%[1]s"
+}
+
+resource "oracle_role" "writer" {
+  name = "writer_%[1]s"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principals = [oracle_role.reader.name, oracle_role.writer.name]
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principals.#", "2"),
+					resource.TestCheckNoResourceAttr("oracle_grant_system_privileges.test_grant", "principal"),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "oracle_grant_system_privileges.test_grant",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"to_grant", "to_revoke"},
+			},
+			// Removing a principal revokes its grants
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "reader" {
+  name = "reader_%[1]s"
+}
+
+resource "oracle_role" "writer" {
+  name = "writer_%[1]s"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principals = [oracle_role.reader.name]
+  privileges = [{ privilege = "CREATE SESSION" }, { privilege = "CREATE TABLE" }]
+}
+`, randString),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("oracle_grant_system_privileges.test_grant", tfjsonpath.New("to_grant"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact(fmt.Sprintf("CREATE TABLE TO READER_%s", strings.ToUpper(randString))),
+						})),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "principals.#", "1"),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "id", fmt.Sprintf("system_privileges:reader_%s:current:CREATE SESSION,CREATE TABLE", randString)),
+					resource.TestCheckResourceAttr("oracle_grant_system_privileges.test_grant", "privileges.#", "2"),
+				),
+			},
+		},
+	})
+}
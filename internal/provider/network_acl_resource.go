@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NetworkACLResource{}
+var _ resource.ResourceWithImportState = &NetworkACLResource{}
+
+// networkACLKind is the kind of the network ACL identifiers.
+const networkACLKind = "network_acl"
+
+func NewNetworkACLResource() resource.Resource {
+	return &NetworkACLResource{}
+}
+
+// NetworkACLResource defines the resource implementation.
+type NetworkACLResource struct {
+	client *oracle.Client
+}
+
+// NetworkACLResourceModel describes the resource data model.
+type NetworkACLResourceModel struct {
+	Host          types.String `tfsdk:"host"`
+	LowerPort     types.Int64  `tfsdk:"lower_port"`
+	UpperPort     types.Int64  `tfsdk:"upper_port"`
+	Principal     types.String `tfsdk:"principal"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+	Privileges    types.Set    `tfsdk:"privileges"`
+	ID            types.String `tfsdk:"id"`
+}
+
+func (r *NetworkACLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_acl"
+}
+
+func (r *NetworkACLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A resource to manage the network privileges of a user or role on a host, such as the privileges `UTL_HTTP` and `UTL_SMTP` require. The privileges are granted as a host access control entry with `DBMS_NETWORK_ACL_ADMIN`.",
+
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				MarkdownDescription: "The host name, domain or IP address, e.g. `api.example.com`, `*.example.com` or `10.0.0.0/24`. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+			},
+			"lower_port": schema.Int64Attribute{
+				MarkdownDescription: "The lower bound of the port range. Must be set together with `upper_port`. If not specified, the privileges apply to all ports. Changing this forces a new resource to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+					int64validator.AlsoRequires(path.MatchRoot("upper_port")),
+				},
+			},
+			"upper_port": schema.Int64Attribute{
+				MarkdownDescription: "The upper bound of the port range. Must be set together with `lower_port`. Changing this forces a new resource to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+					int64validator.AlsoRequires(path.MatchRoot("lower_port")),
+					int64validator.AtLeastSumOf(path.MatchRoot("lower_port")),
+				},
+			},
+			"principal":      aclPrincipalAttribute(),
+			"principal_type": aclPrincipalTypeAttribute(),
+			"privileges": schema.SetAttribute{
+				MarkdownDescription: "The network privileges to grant to the principal: `connect`, `resolve`, `http`, `http_proxy`, `smtp` or `jdwp`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("connect", "resolve", "http", "http_proxy", "smtp", "jdwp")),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Network ACL identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NetworkACLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NetworkACLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NetworkACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace := data.hostACE()
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &ace.Privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AppendHostACE(ace)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to append host ACE, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(networkACLID(ace))
+
+	tflog.Trace(ctx, "created a network ACL resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NetworkACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, err := r.client.GetHostACEPrivileges(data.hostACE())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read host ACE, got error: %s", err))
+		return
+	}
+	if len(privileges) == 0 {
+		// If the ACE is not found, remove it from the state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	privilegesValue, diags := types.SetValueFrom(ctx, types.StringType, privileges)
+	resp.Diagnostics.Append(diags...)
+	data.Privileges = privilegesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkACLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state NetworkACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, prior []string
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the privileges are updated in place
+	added, removed := aclPrivilegeChanges(prior, planned)
+	ace := data.hostACE()
+	if len(added) > 0 {
+		ace.Privileges = added
+		if err := r.client.AppendHostACE(ace); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to append host ACE, got error: %s", err))
+			return
+		}
+	}
+	if len(removed) > 0 {
+		ace.Privileges = removed
+		if err := r.client.RemoveHostACE(ace); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove host ACE, got error: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkACLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NetworkACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace := data.hostACE()
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &ace.Privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveHostACE(ace)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove host ACE, got error: %s", err))
+		return
+	}
+}
+
+func (r *NetworkACLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseResourceID(req.ID, networkACLKind, "host:lower_port:upper_port:principal:principal_type", "lower_port", "upper_port")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	ace := oracle.HostACE{Host: parts[0], Principal: parts[3], PrincipalType: parts[4]}
+	ports := []path.Path{path.Root("lower_port"), path.Root("upper_port")}
+	for i, part := range parts[1:3] {
+		if part == "" {
+			continue
+		}
+		port, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("expected numeric %s, got %q", ports[i], part))
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, ports[i], port)...)
+		if i == 0 {
+			ace.LowerPort = int(port)
+		} else {
+			ace.UpperPort = int(port)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), networkACLID(ace))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("host"), ace.Host)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), ace.Principal)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_type"), ace.PrincipalType)...)
+}
+
+// hostACE returns the host ACE identified by the model, without privileges.
+func (data NetworkACLResourceModel) hostACE() oracle.HostACE {
+	return oracle.HostACE{
+		Host:          data.Host.ValueString(),
+		LowerPort:     int(data.LowerPort.ValueInt64()),
+		UpperPort:     int(data.UpperPort.ValueInt64()),
+		Principal:     data.Principal.ValueString(),
+		PrincipalType: data.PrincipalType.ValueString(),
+	}
+}
+
+// networkACLID returns the canonical identifier of a host ACE, where ports
+// that are not set are empty.
+func networkACLID(ace oracle.HostACE) string {
+	lowerPort, upperPort := "", ""
+	if ace.LowerPort != 0 {
+		lowerPort = strconv.Itoa(ace.LowerPort)
+	}
+	if ace.UpperPort != 0 {
+		upperPort = strconv.Itoa(ace.UpperPort)
+	}
+	return formatResourceID(networkACLKind, strings.ToLower(ace.Host), lowerPort, upperPort, ace.Principal, ace.PrincipalType)
+}
+
+// aclPrincipalAttribute returns the schema of the principal of an ACE.
+func aclPrincipalAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The user or role to whom the privileges are granted. Changing this forces a new resource to be created.",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			requiresReplaceIfIdentifierChanged(),
+		},
+	}
+}
+
+// aclPrincipalTypeAttribute returns the schema of the principal type of an ACE.
+func aclPrincipalTypeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The type of the principal: `database` for database users and roles, or `xs` for Real Application Security users and roles. If not specified, the default is `database`. Changing this forces a new resource to be created.",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString("database"),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.OneOf("database", "xs"),
+		},
+	}
+}
+
+// aclPrivilegeChanges returns the privileges of an ACE that are added and
+// removed when the prior privileges are replaced by the planned ones.
+func aclPrivilegeChanges(prior, planned []string) (added, removed []string) {
+	for _, privilege := range planned {
+		if !slices.Contains(prior, privilege) {
+			added = append(added, privilege)
+		}
+	}
+	for _, privilege := range prior {
+		if !slices.Contains(planned, privilege) {
+			removed = append(removed, privilege)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestNetworkACLID(t *testing.T) {
+	assert.Equal(t, "network_acl:api.example.com:443:8443:app_user:database", networkACLID(oracle.HostACE{
+		Host:          "API.example.com",
+		LowerPort:     443,
+		UpperPort:     8443,
+		Principal:     "app_user",
+		PrincipalType: "database",
+	}))
+	assert.Equal(t, "network_acl:*.example.com:::app_user:database", networkACLID(oracle.HostACE{
+		Host:          "*.example.com",
+		Principal:     "app_user",
+		PrincipalType: "database",
+	}))
+}
+
+func TestACLPrivilegeChanges(t *testing.T) {
+	added, removed := aclPrivilegeChanges([]string{"connect", "resolve"}, []string{"connect", "http"})
+	assert.Equal(t, []string{"http"}, added)
+	assert.Equal(t, []string{"resolve"}, removed)
+
+	added, removed = aclPrivilegeChanges([]string{"connect"}, []string{"connect"})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestAcc_NetworkACLResource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_network_acl" "test_acl" {
+  host       = "api.example.com"
+  lower_port = 443
+  upper_port = 443
+  principal  = oracle_user.test_user.username
+  privileges = ["connect", "resolve"]
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_network_acl.test_acl", "id", fmt.Sprintf("network_acl:api.example.com:443:443:testuser_%s:database", randString)),
+					resource.TestCheckResourceAttr("oracle_network_acl.test_acl", "principal_type", "database"),
+					resource.TestCheckResourceAttr("oracle_network_acl.test_acl", "privileges.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "oracle_network_acl.test_acl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The identifier of an import is canonical whatever the case of the host
+			{
+				ResourceName:  "oracle_network_acl.test_acl",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("network_acl:API.EXAMPLE.COM:443:443:testuser_%s:database", randString),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					expected := fmt.Sprintf("network_acl:api.example.com:443:443:testuser_%s:database", randString)
+					if len(states) != 1 || states[0].ID != expected {
+						return fmt.Errorf("expected imported id %q, got %v", expected, states)
+					}
+					return nil
+				},
+			},
+			// Privileges are updated in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_network_acl" "test_acl" {
+  host       = "api.example.com"
+  lower_port = 443
+  upper_port = 443
+  principal  = oracle_user.test_user.username
+  privileges = ["connect", "http"]
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_network_acl.test_acl", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("oracle_network_acl.test_acl", "privileges.*", "http"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAcc_WalletACLResource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "password"
+}
+
+resource "oracle_wallet_acl" "test_acl" {
+  wallet_path = "file:/tmp/wallet_%s"
+  principal   = oracle_user.test_user.username
+  privileges  = ["use_client_certificates"]
+}
+`, randString, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_wallet_acl.test_acl", "id", fmt.Sprintf("wallet_acl:file%%3A/tmp/wallet_%s:testuser_%s:database", randString, randString)),
+					resource.TestCheckResourceAttr("oracle_wallet_acl.test_acl", "privileges.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "oracle_wallet_acl.test_acl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requiresReplaceIfIdentifierChanged returns a plan modifier that replaces the
+// resource when an identifier changes. Oracle identifiers are case-insensitive,
+// so a change in case alone is applied in place.
+func requiresReplaceIfIdentifierChanged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the identifier (ignoring case) requires replacing the resource.",
+		"Changing the identifier (ignoring case) requires replacing the resource.",
+	)
+}
+
+// identifierValue returns the identifier read from the database, keeping the
+// value from state when both only differ in case.
+func identifierValue(prior types.String, value string) types.String {
+	if strings.EqualFold(prior.ValueString(), value) {
+		return prior
+	}
+	return types.StringValue(strings.ToLower(value))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// principalAttribute returns the schema of the principal attribute of the
+// grant resources that also accept principals.
+func principalAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The user or role to whom the privileges are granted. Exactly one of `principal` and `principals` must be set. Changing this revokes the grants from the previous principal.",
+		Optional:            true,
+		PlanModifiers: []planmodifier.String{
+			requiresReplaceIfIdentifierChanged(),
+		},
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("principals")),
+			publicPrincipalValidator{},
+		},
+	}
+}
+
+// principalsAttribute returns the schema of the principals attribute, which
+// grants the same privileges to several users or roles.
+func principalsAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "The users or roles to whom the privileges are granted, as an alternative to `principal`. " +
+			"The grants of each principal are compared with `privileges` on their own, and principals missing the same privileges are granted them in a single `GRANT ... TO` statement. " +
+			"Removing a principal revokes the grants from it.",
+		ElementType: types.StringType,
+		Optional:    true,
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+			setvalidator.ValueStringsAre(publicPrincipalValidator{}),
+		},
+	}
+}
+
+// grantPrincipals returns the principals of a grant resource, either
+// principal or the elements of principals, sorted by name.
+func grantPrincipals(ctx context.Context, principal types.String, principals types.Set) ([]string, diag.Diagnostics) {
+	if !principals.IsNull() && !principals.IsUnknown() {
+		var values []string
+		diags := principals.ElementsAs(ctx, &values, false)
+		sort.Slice(values, func(i, j int) bool {
+			return strings.ToLower(values[i]) < strings.ToLower(values[j])
+		})
+		return values, diags
+	}
+	return []string{principal.ValueString()}, nil
+}
+
+// planGrants returns the grant plan of a single principal, or the combined
+// plan of several principals in which each entry names its principal.
+func planGrants(principal types.String, principals []string, plan func(principal string) (oracle.GrantPlan, error)) (oracle.GrantPlan, error) {
+	if !principal.IsNull() {
+		return plan(principal.ValueString())
+	}
+	return oracle.PlanForPrincipals(principals, plan)
+}
+
+// commonPrivileges combines the privileges read for several principals into
+// the privileges attribute. A privilege of the prior value is only kept when
+// every principal holds it with the same options, and a privilege outside of
+// the prior value is added when any principal holds it, so that the grants of
+// each principal that differ show up as drift.
+func commonPrivileges(current [][]oracle.Privilege, prior []oracle.Privilege) []oracle.Privilege {
+	if len(current) == 1 {
+		return current[0]
+	}
+
+	privileges := []oracle.Privilege{}
+	for _, priorPrivilege := range prior {
+		privilege, kept := priorPrivilege, true
+		for _, principalPrivileges := range current {
+			granted, found := oracle.FindPrivilege(principalPrivileges, priorPrivilege.Name)
+			if !found {
+				kept = false
+				break
+			}
+			if granted != privilege && privilege == priorPrivilege {
+				privilege = granted
+			}
+		}
+		if kept {
+			privileges = append(privileges, privilege)
+		}
+	}
+	for _, principalPrivileges := range current {
+		for _, granted := range principalPrivileges {
+			if _, found := oracle.FindPrivilege(prior, granted.Name); found {
+				continue
+			}
+			if _, found := oracle.FindPrivilege(privileges, granted.Name); !found {
+				privileges = append(privileges, granted)
+			}
+		}
+	}
+	return privileges
+}
+
+// commonColumnPrivileges combines the column privileges read for several
+// principals like commonPrivileges: a column of the prior value is only kept
+// when every principal holds it, and other columns are added when any
+// principal holds them.
+func commonColumnPrivileges(current []map[string][]string, prior map[string][]string) map[string][]string {
+	if len(current) == 1 {
+		return current[0]
+	}
+
+	privileges := map[string][]string{}
+	for _, principalPrivileges := range current {
+		for privilege, columns := range principalPrivileges {
+			priorColumns := oracle.ColumnsFor(prior, privilege)
+			for _, column := range columns {
+				if oracle.ContainsFold(privileges[privilege], column) {
+					continue
+				}
+				if !oracle.ContainsFold(priorColumns, column) || heldByAll(current, privilege, column) {
+					privileges[privilege] = append(privileges[privilege], column)
+				}
+			}
+		}
+	}
+	return privileges
+}
+
+// heldByAll checks if every principal holds a column privilege.
+func heldByAll(current []map[string][]string, privilege, column string) bool {
+	for _, principalPrivileges := range current {
+		if !oracle.ContainsFold(oracle.ColumnsFor(principalPrivileges, privilege), column) {
+			return false
+		}
+	}
+	return true
+}
+
+// importPrincipals sets principal, or principals when an imported identifier
+// names several principals.
+func importPrincipals(ctx context.Context, state *tfsdk.State, principals []string) diag.Diagnostics {
+	if len(principals) == 1 {
+		return state.SetAttribute(ctx, path.Root("principal"), principals[0])
+	}
+	return state.SetAttribute(ctx, path.Root("principals"), principals)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestCommonPrivileges(t *testing.T) {
+	prior := []oracle.Privilege{{Name: "SELECT", GrantOption: true}, {Name: "INSERT"}}
+
+	// A single principal is read as is
+	current := [][]oracle.Privilege{{{Name: "SELECT"}}}
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT"}}, commonPrivileges(current, prior))
+
+	// Privileges of the prior value are kept when every principal holds them
+	current = [][]oracle.Privilege{
+		{{Name: "SELECT", GrantOption: true}, {Name: "INSERT"}},
+		{{Name: "SELECT", GrantOption: true}, {Name: "INSERT"}},
+	}
+	assert.Equal(t, prior, commonPrivileges(current, prior))
+
+	// A privilege missing for one principal or granted with other options shows up as drift
+	current = [][]oracle.Privilege{
+		{{Name: "SELECT", GrantOption: true}, {Name: "INSERT"}},
+		{{Name: "SELECT"}, {Name: "DELETE"}},
+	}
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT"}, {Name: "DELETE"}}, commonPrivileges(current, prior))
+}
+
+func TestCommonColumnPrivileges(t *testing.T) {
+	prior := map[string][]string{"UPDATE": {"email", "phone"}}
+	current := []map[string][]string{
+		{"UPDATE": {"EMAIL", "PHONE"}},
+		{"UPDATE": {"EMAIL", "NAME"}, "INSERT": {"ID"}},
+	}
+	assert.Equal(t, map[string][]string{"UPDATE": {"EMAIL", "NAME"}, "INSERT": {"ID"}}, commonColumnPrivileges(current, prior))
+}
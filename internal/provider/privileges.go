@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// privilegeOptions describes the grant options supported by the privileges
+// attribute of a grant resource.
+type privilegeOptions struct {
+	kind      oracle.PrivilegeKind // The catalog the privilege names are checked against.
+	admin     bool                 // with_admin_option, for system and schema privileges.
+	grant     bool                 // with_grant_option, for object and directory privileges.
+	hierarchy bool                 // hierarchy_option, for SELECT on object tables and views.
+}
+
+var (
+	systemPrivilegeOptions    = privilegeOptions{kind: oracle.SystemPrivilegeKind, admin: true}
+	objectPrivilegeOptions    = privilegeOptions{kind: oracle.ObjectPrivilegeKind, grant: true, hierarchy: true}
+	directoryPrivilegeOptions = privilegeOptions{kind: oracle.DirectoryPrivilegeKind, grant: true}
+)
+
+// attributeTypes returns the attribute types of a privilege object.
+func (o privilegeOptions) attributeTypes() map[string]attr.Type {
+	attributeTypes := map[string]attr.Type{"privilege": types.StringType}
+	if o.admin {
+		attributeTypes["with_admin_option"] = types.BoolType
+	}
+	if o.grant {
+		attributeTypes["with_grant_option"] = types.BoolType
+	}
+	if o.hierarchy {
+		attributeTypes["hierarchy_option"] = types.BoolType
+	}
+	return attributeTypes
+}
+
+// objectType returns the type of a privilege object.
+func (o privilegeOptions) objectType() types.ObjectType {
+	return types.ObjectType{AttrTypes: o.attributeTypes()}
+}
+
+// attribute returns the schema of a privileges attribute with the given description.
+func (o privilegeOptions) attribute(description string) schema.SetNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"privilege": schema.StringAttribute{
+			MarkdownDescription: "The name of the privilege, e.g. `CREATE SESSION` or `SELECT`.",
+			Required:            true,
+		},
+	}
+	if o.admin {
+		attributes["with_admin_option"] = optionAttribute("Whether the privilege is granted `WITH ADMIN OPTION`, allowing the principal to grant it to others.")
+	}
+	if o.grant {
+		attributes["with_grant_option"] = optionAttribute("Whether the privilege is granted `WITH GRANT OPTION`, allowing the principal to grant it to others.")
+	}
+	if o.hierarchy {
+		attributes["hierarchy_option"] = optionAttribute("Whether `SELECT` is granted `WITH HIERARCHY OPTION`, extending it to all subobjects of an object table or view.")
+	}
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+		Validators: []validator.Set{
+			privilegeNamesValidator{kind: o.kind},
+		},
+	}
+}
+
+// optionAttribute returns the schema of a grant option of a privilege.
+func optionAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: description + " If not specified, the default is `false`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+}
+
+// privileges converts a privileges attribute to a list of privileges.
+func (o privilegeOptions) privileges(ctx context.Context, value types.Set) ([]oracle.Privilege, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	privileges := []oracle.Privilege{}
+	for _, element := range value.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			diags.AddError("Unexpected Privilege Type", "Expected privileges to be a set of objects. Please report this issue to the provider developers.")
+			return nil, diags
+		}
+		attributes := object.Attributes()
+		privilege := oracle.Privilege{Name: attributes["privilege"].(types.String).ValueString()}
+		if o.admin {
+			privilege.AdminOption = attributes["with_admin_option"].(types.Bool).ValueBool()
+		}
+		if o.grant {
+			privilege.GrantOption = attributes["with_grant_option"].(types.Bool).ValueBool()
+		}
+		if o.hierarchy {
+			privilege.HierarchyOption = attributes["hierarchy_option"].(types.Bool).ValueBool()
+		}
+		privileges = append(privileges, privilege)
+	}
+	return privileges, diags
+}
+
+// value converts a list of privileges read from the database to a privileges
+// attribute. Privilege names keep the spelling of the prior value when they
+// only differ in case.
+func (o privilegeOptions) value(ctx context.Context, privileges []oracle.Privilege, prior types.Set) (types.Set, diag.Diagnostics) {
+	priorPrivileges, diags := o.privileges(ctx, prior)
+	if diags.HasError() {
+		return types.SetNull(o.objectType()), diags
+	}
+
+	elements := []attr.Value{}
+	for _, privilege := range privileges {
+		name := privilege.Name
+		for _, priorPrivilege := range priorPrivileges {
+			if strings.EqualFold(priorPrivilege.Name, name) {
+				name = priorPrivilege.Name
+			}
+		}
+		attributes := map[string]attr.Value{"privilege": types.StringValue(name)}
+		if o.admin {
+			attributes["with_admin_option"] = types.BoolValue(privilege.AdminOption)
+		}
+		if o.grant {
+			attributes["with_grant_option"] = types.BoolValue(privilege.GrantOption)
+		}
+		if o.hierarchy {
+			attributes["hierarchy_option"] = types.BoolValue(privilege.HierarchyOption)
+		}
+		element, d := types.ObjectValue(o.attributeTypes(), attributes)
+		diags.Append(d...)
+		elements = append(elements, element)
+	}
+	if diags.HasError() {
+		return types.SetNull(o.objectType()), diags
+	}
+
+	value, d := types.SetValue(o.objectType(), elements)
+	diags.Append(d...)
+	return value, diags
+}
+
+// parse parses privileges stored as strings with WITH ... OPTION suffixes.
+// Older versions of the provider read every grantable object privilege as
+// WITH ADMIN OPTION, so the admin option is treated as the grant option for
+// privileges that only support the latter.
+func (o privilegeOptions) parse(values []string) []oracle.Privilege {
+	privileges := make([]oracle.Privilege, len(values))
+	for i, value := range values {
+		privilege := oracle.ParsePrivilege(value)
+		if o.grant && privilege.AdminOption {
+			privilege.AdminOption = false
+			privilege.GrantOption = true
+		}
+		if o.admin && privilege.GrantOption {
+			privilege.GrantOption = false
+			privilege.AdminOption = true
+		}
+		privileges[i] = privilege
+	}
+	return privileges
+}
+
+// upgrader returns a state upgrader from schema version 0, in which
+// privileges were a set of strings such as "SELECT WITH GRANT OPTION", to the
+// given current schema.
+func (o privilegeOptions) upgrader(current schema.Schema) resource.StateUpgrader {
+	prior := schema.Schema{Attributes: map[string]schema.Attribute{}}
+	for name, attribute := range current.Attributes {
+		prior.Attributes[name] = attribute
+	}
+	prior.Attributes["privileges"] = schema.SetAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Computed:    true,
+	}
+
+	return resource.StateUpgrader{
+		PriorSchema: &prior,
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var values []string
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("privileges"), &values)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			privileges, diags := o.value(ctx, o.parse(values), types.SetNull(o.objectType()))
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			privilegesValue, err := privileges.ToTerraformValue(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+
+			// Every other attribute is unchanged.
+			var attributes map[string]tftypes.Value
+			if err := req.State.Raw.As(&attributes); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+			attributes["privileges"] = privilegesValue
+			resp.State.Raw = tftypes.NewValue(current.Type().TerraformType(ctx), attributes)
+		},
+	}
+}
+
+// withoutIgnoredPrivileges removes the privileges matching an ignore pattern
+// from current, unless they are part of the prior state, so that ignored
+// grants do not show up as drift.
+func withoutIgnoredPrivileges(current, prior []oracle.Privilege, ignore []string) []oracle.Privilege {
+	var priorNames []string
+	for _, privilege := range prior {
+		priorNames = append(priorNames, privilege.Name)
+	}
+
+	privileges := []oracle.Privilege{}
+	for _, privilege := range current {
+		if oracle.IsIgnored(ignore, privilege.Name) && !oracle.ContainsFold(priorNames, privilege.Name) {
+			continue
+		}
+		privileges = append(privileges, privilege)
+	}
+	return privileges
+}
+
+// checkCatalog checks the privilege names against the privilege catalog of
+// the connected database, which unlike privilegeNamesValidator knows the
+// database version and, if enabled, the live dictionary.
+func (o privilegeOptions) checkCatalog(client *oracle.Client, privileges []oracle.Privilege) diag.Diagnostics {
+	var diags diag.Diagnostics
+	catalog, err := client.PrivilegeCatalog()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the privilege catalog, got error: %s", err))
+		return diags
+	}
+	for _, privilege := range privileges {
+		if err := catalog.Check(o.kind, privilege.Name); err != nil {
+			diags.AddAttributeError(path.Root("privileges"), "Unknown Privilege", fmt.Sprintf("%s (checked against the %s)", err, catalog.Source))
+		}
+	}
+	return diags
+}
+
+// embeddedPrivileges returns the privileges of every embedded catalog, read once.
+var embeddedPrivileges = sync.OnceValues(oracle.AllEmbeddedPrivileges)
+
+// privilegeNamesValidator checks the privilege names of a privileges
+// attribute against the embedded privilege catalogs, so that typos fail at
+// plan time instead of with ORA-00990 at apply time.
+type privilegeNamesValidator struct {
+	kind oracle.PrivilegeKind
+}
+
+func (v privilegeNamesValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("privilege names must be known %s privileges", v.kind)
+}
+
+func (v privilegeNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v privilegeNamesValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	catalog, err := embeddedPrivileges()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Privilege Catalog", err.Error())
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		name, ok := object.Attributes()["privilege"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if err := catalog.Check(v.kind, name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Unknown Privilege", err.Error())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestPrivilegeOptions_Upgrader(t *testing.T) {
+	ctx := context.Background()
+	r := &GrantObjectPrivilegesResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+	priorValues := map[string]tftypes.Value{}
+	for name, attributeType := range priorType.AttributeTypes {
+		priorValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	priorValues["principal"] = tftypes.NewValue(tftypes.String, "testuser")
+	priorValues["object"] = tftypes.NewValue(tftypes.String, "test_table")
+	priorValues["privileges"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "SELECT WITH ADMIN OPTION"),
+		tftypes.NewValue(tftypes.String, "UPDATE"),
+	})
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: tftypes.NewValue(priorType, priorValues)},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data GrantObjectPrivilegesResourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+	assert.Equal(t, "testuser", data.Principal.ValueString())
+	assert.Equal(t, "object_privileges:testuser::test_table::current", data.ID.ValueString())
+
+	privileges, diags := objectPrivilegeOptions.privileges(ctx, data.Privileges)
+	assert.False(t, diags.HasError())
+	assert.ElementsMatch(t, []oracle.Privilege{
+		{Name: "SELECT", GrantOption: true},
+		{Name: "UPDATE"},
+	}, privileges)
+}
+
+func TestParsePrivilegeOptions(t *testing.T) {
+	assert.Equal(t, []oracle.Privilege{{Name: "CREATE SESSION", AdminOption: true}}, systemPrivilegeOptions.parse([]string{"create session with admin option"}))
+	assert.Equal(t, []oracle.Privilege{{Name: "READ", GrantOption: true}}, directoryPrivilegeOptions.parse([]string{"READ WITH GRANT OPTION"}))
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT", GrantOption: true, HierarchyOption: true}}, objectPrivilegeOptions.parse([]string{"SELECT WITH HIERARCHY OPTION WITH GRANT OPTION"}))
+}
+
+func TestPrivilegeNamesValidator(t *testing.T) {
+	ctx := context.Background()
+	privileges, diags := systemPrivilegeOptions.value(ctx, []oracle.Privilege{{Name: "CREATE SESSION"}, {Name: "CREAT TABLE"}}, types.SetNull(systemPrivilegeOptions.objectType()))
+	assert.False(t, diags.HasError())
+
+	var resp validator.SetResponse
+	privilegeNamesValidator{kind: oracle.SystemPrivilegeKind}.ValidateSet(ctx, validator.SetRequest{Path: path.Root("privileges"), ConfigValue: privileges}, &resp)
+	assert.Equal(t, 1, resp.Diagnostics.ErrorsCount())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `did you mean "CREATE TABLE"?`)
+
+	resp = validator.SetResponse{}
+	privilegeNamesValidator{kind: oracle.SystemPrivilegeKind}.ValidateSet(ctx, validator.SetRequest{Path: path.Root("privileges"), ConfigValue: types.SetUnknown(systemPrivilegeOptions.objectType())}, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
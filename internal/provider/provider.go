@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
@@ -40,6 +42,8 @@ type OracleRDBMSProviderModel struct {
 	Password types.String `tfsdk:"password"`
 	Port     types.String `tfsdk:"port"`
 	Service  types.String `tfsdk:"service"`
+
+	PrivilegeCatalog types.String `tfsdk:"privilege_catalog"`
 }
 
 func (p *OracleRDBMSProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -72,6 +76,15 @@ func (p *OracleRDBMSProvider) Schema(ctx context.Context, req provider.SchemaReq
 				MarkdownDescription: "service name of the Oracle database server.",
 				Optional:            true,
 			},
+			"privilege_catalog": schema.StringAttribute{
+				MarkdownDescription: "catalog that privilege names of grant resources are checked against at plan time. " +
+					"`embedded` uses the catalog of `SYSTEM_PRIVILEGE_MAP` and `TABLE_PRIVILEGE_MAP` names shipped with the provider for the database version, " +
+					"`live` reads them from the database instead. If not specified, the default is `embedded`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("embedded", "live"),
+				},
+			},
 		},
 	}
 }
@@ -232,6 +245,8 @@ func (p *OracleRDBMSProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	client.LivePrivilegeCatalog = config.PrivilegeCatalog.ValueString() == "live"
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -242,9 +257,13 @@ func (p *OracleRDBMSProvider) Resources(ctx context.Context) []func() resource.R
 		NewRoleResource,
 		NewGrantSystemPrivilegesResource,
 		NewGrantObjectPrivilegesResource,
+		NewGrantBulkObjectPrivilegesResource,
 		NewGrantDirectoryPrivilegesResource,
+		NewGrantSchemaPrivilegesResource,
 		NewGrantRolesResource,
 		NewDirectoryResource,
+		NewNetworkACLResource,
+		NewWalletACLResource,
 		NewSqlResource,
 	}
 }
@@ -254,7 +273,11 @@ func (p *OracleRDBMSProvider) EphemeralResources(ctx context.Context) []func() e
 }
 
 func (p *OracleRDBMSProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewUserPasswordVerifierDataSource,
+		NewRoleDataSource,
+		NewEffectivePrivilegesDataSource,
+	}
 }
 
 func (p *OracleRDBMSProvider) Functions(ctx context.Context) []func() function.Function {
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// allowPublicAttribute returns the schema of the allow_public attribute
+// shared by the grant resources.
+func allowPublicAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether `principal` may be `PUBLIC`, which gives the grants to every user of the database. " +
+			"For `PUBLIC`, `enforce` mode only revokes grants that this resource made, never the grants Oracle makes to `PUBLIC` by default. " +
+			"If not specified, the default is `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// publicPrincipalValidator requires allow_public to be set when the principal is PUBLIC.
+type publicPrincipalValidator struct{}
+
+func (v publicPrincipalValidator) Description(ctx context.Context) string {
+	return "principal can only be PUBLIC when allow_public is true"
+}
+
+func (v publicPrincipalValidator) MarkdownDescription(ctx context.Context) string {
+	return "principal can only be `PUBLIC` when `allow_public` is `true`"
+}
+
+func (v publicPrincipalValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !oracle.IsPublic(req.ConfigValue.ValueString()) {
+		return
+	}
+
+	var allowPublic types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_public"), &allowPublic)...)
+	if resp.Diagnostics.HasError() || allowPublic.IsUnknown() || allowPublic.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Grant to PUBLIC Not Allowed",
+		"Granting to PUBLIC gives the grants to every user of the database. Set allow_public = true to confirm.",
+	)
+}
+
+// managedPrivileges returns the names of the privileges stored in the prior
+// state of a grant resource, which are the only privileges that enforce mode
+// may revoke from PUBLIC.
+func (o privilegeOptions) managedPrivileges(ctx context.Context, state tfsdk.State) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if state.Raw.IsNull() {
+		return nil, diags
+	}
+
+	var prior types.Set
+	diags.Append(state.GetAttribute(ctx, path.Root("privileges"), &prior)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	privileges, d := o.privileges(ctx, prior)
+	diags.Append(d...)
+	var names []string
+	for _, privilege := range privileges {
+		names = append(names, privilege.Name)
+	}
+	return names, diags
+}
+
+// withoutUnmanagedPublic removes the privileges that are not part of the
+// prior value when the principal is PUBLIC, so that the grants Oracle makes to
+// PUBLIC by default do not show up as drift. Imported grants have no prior
+// value and keep every privilege.
+func withoutUnmanagedPublic(principal string, current, prior []oracle.Privilege, imported bool) []oracle.Privilege {
+	if !oracle.IsPublic(principal) || imported {
+		return current
+	}
+	return withoutUnmanaged(current, prior)
+}
+
+// withoutUnmanaged removes the privileges that are not part of the prior
+// value, i.e. that were not granted by the resource.
+func withoutUnmanaged(current, prior []oracle.Privilege) []oracle.Privilege {
+	privileges := []oracle.Privilege{}
+	for _, privilege := range current {
+		if _, found := oracle.FindPrivilege(prior, privilege.Name); found {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+func TestWithoutUnmanagedPublic(t *testing.T) {
+	current := []oracle.Privilege{{Name: "EXECUTE"}, {Name: "SELECT", GrantOption: true}}
+	prior := []oracle.Privilege{{Name: "select"}}
+
+	assert.Equal(t, []oracle.Privilege{{Name: "SELECT", GrantOption: true}}, withoutUnmanagedPublic("public", current, prior, false))
+	assert.Equal(t, current, withoutUnmanagedPublic("PUBLIC", current, prior, true))
+	assert.Equal(t, current, withoutUnmanagedPublic("APP_USER", current, prior, false))
+	assert.Equal(t, []oracle.Privilege{}, withoutUnmanagedPublic("PUBLIC", current, nil, false))
+}
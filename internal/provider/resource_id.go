@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// splitResourceID splits a colon-separated resource identifier into the
+// fields named by format, e.g. "principal:owner:object". Every field must be
+// non-empty unless it is listed in optional.
+func splitResourceID(id, format string, optional ...string) ([]string, error) {
+	fields := strings.Split(format, ":")
+	parts := strings.Split(id, ":")
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("expected identifier with format %q, got %q", format, id)
+	}
+	for i, part := range parts {
+		if part == "" && !slices.Contains(optional, fields[i]) {
+			return nil, fmt.Errorf("expected identifier with format %q, got %q: %s must not be empty", format, id, fields[i])
+		}
+	}
+	return parts, nil
+}
+
+var (
+	idPartEscaper   = strings.NewReplacer("%", "%25", ":", "%3A")
+	idPartUnescaper = strings.NewReplacer("%3A", ":", "%25", "%")
+	idListEscaper   = strings.NewReplacer("%", "%25", ",", "%2C")
+	idListUnescaper = strings.NewReplacer("%2C", ",", "%25", "%")
+)
+
+// formatResourceID returns the canonical identifier of a grant resource: the
+// kind of the resource followed by its colon-separated parts, e.g.
+// "object_privileges:app_user:hr:employees:TABLE:current". Colons in the
+// parts are escaped, so that quoted names and empty parts cannot shift the
+// other parts.
+func formatResourceID(kind string, parts ...string) string {
+	escaped := []string{kind}
+	for _, part := range parts {
+		escaped = append(escaped, idPartEscaper.Replace(part))
+	}
+	return strings.Join(escaped, ":")
+}
+
+// parseResourceID parses a canonical identifier of the given kind into the
+// fields named by format, e.g. "principals:owner:object". Every field must be
+// non-empty unless it is listed in optional.
+func parseResourceID(id, kind, format string, optional ...string) ([]string, error) {
+	if !isResourceID(id, kind) {
+		return nil, fmt.Errorf("expected identifier with format %q, got %q", kind+":"+format, id)
+	}
+
+	parts, err := splitResourceID(strings.TrimPrefix(id, kind+":"), format, optional...)
+	if err != nil {
+		return nil, fmt.Errorf("expected identifier with format %q, got %q", kind+":"+format, id)
+	}
+	for i, part := range parts {
+		parts[i] = idPartUnescaper.Replace(part)
+	}
+	return parts, nil
+}
+
+// isResourceID checks if id is a canonical identifier of the given kind.
+func isResourceID(id, kind string) bool {
+	return strings.HasPrefix(id, kind+":")
+}
+
+// parseImportID parses the identifier of an imported grant, which is either
+// a canonical identifier or, as accepted by older versions of the provider, a
+// colon-separated identifier with the fields of legacyFormat. The fields of
+// format that are missing from a legacy identifier are empty.
+func parseImportID(id, kind, format, legacyFormat string, optional ...string) ([]string, error) {
+	if isResourceID(id, kind) {
+		return parseResourceID(id, kind, format, optional...)
+	}
+
+	legacyParts, err := splitResourceID(id, legacyFormat, optional...)
+	if err != nil {
+		return nil, fmt.Errorf("expected identifier with format %q or %q, got %q", kind+":"+format, legacyFormat, id)
+	}
+	fields := strings.Split(format, ":")
+	parts := make([]string, len(fields))
+	for i, field := range strings.Split(legacyFormat, ":") {
+		parts[slices.Index(fields, field)] = legacyParts[i]
+	}
+	return parts, nil
+}
+
+// joinIDList returns a part of a resource identifier that holds several
+// values, such as principals, separated by commas.
+func joinIDList(values []string) string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = idListEscaper.Replace(value)
+	}
+	return strings.Join(escaped, ",")
+}
+
+// joinSortedIDList returns a part of a resource identifier that holds a set
+// of values, such as roles, sorted so that their order does not matter.
+func joinSortedIDList(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	return joinIDList(sorted)
+}
+
+// splitIDList returns the values of a part of a resource identifier that was
+// created by joinIDList.
+func splitIDList(part string) []string {
+	if part == "" {
+		return nil
+	}
+	values := strings.Split(part, ",")
+	for i, value := range values {
+		values[i] = idListUnescaper.Replace(value)
+	}
+	return values
+}
+
+// planResourceID marks the identifier of a grant as unknown when one of the
+// given attributes, which are part of the identifier, changes in place.
+func planResourceID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributes ...string) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, attribute := range attributes {
+		attributePath := tftypes.NewAttributePath().WithAttributeName(attribute)
+		planned, _, err := tftypes.WalkAttributePath(req.Plan.Raw, attributePath)
+		if err != nil {
+			continue
+		}
+		prior, _, err := tftypes.WalkAttributePath(req.State.Raw, attributePath)
+		if err != nil {
+			continue
+		}
+		if !planned.(tftypes.Value).Equal(prior.(tftypes.Value)) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+			return
+		}
+	}
+}
+
+// identifiedModel is the data model of a grant resource with a canonical identifier.
+type identifiedModel interface {
+	resourceID(ctx context.Context) (string, diag.Diagnostics)
+}
+
+// upgradeResourceID returns a state upgrader that runs upgrader, or copies
+// the prior state if upgrader only declares a prior schema, and replaces the
+// identifier of older versions of the provider with the canonical identifier.
+func upgradeResourceID[T identifiedModel](upgrader resource.StateUpgrader) resource.StateUpgrader {
+	upgrade := upgrader.StateUpgrader
+	upgrader.StateUpgrader = func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+		if upgrade != nil {
+			upgrade(ctx, req, resp)
+		} else {
+			resp.State.Raw = req.State.Raw
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var data T
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id, diags := data.resourceID(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	}
+	return upgrader
+}
+
+// priorSchemaUpgrader returns a state upgrader from an older schema version
+// with the attributes of the current schema, to be used with upgradeResourceID.
+func priorSchemaUpgrader(current schema.Schema) resource.StateUpgrader {
+	prior := schema.Schema{Attributes: map[string]schema.Attribute{}}
+	for name, attribute := range current.Attributes {
+		prior.Attributes[name] = attribute
+	}
+	return resource.StateUpgrader{PriorSchema: &prior}
+}
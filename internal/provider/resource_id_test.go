@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceID(t *testing.T) {
+	// Quoted names may contain separators and empty parts keep their position
+	principals := []string{"app:reader", "app,writer", "100%"}
+	id := formatResourceID("object_privileges", joinIDList(principals), "", "my:table", "", "current")
+	assert.Equal(t, "object_privileges:app%3Areader,app%252Cwriter,100%2525::my%3Atable::current", id)
+
+	parts, err := parseResourceID(id, "object_privileges", "principals:owner:object:object_type:container_scope", "owner", "object_type")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "my:table", "", "current"}, parts[1:])
+	assert.Equal(t, principals, splitIDList(parts[0]))
+
+	_, err = parseResourceID(id, "directory_privileges", "principals:owner:object:object_type:container_scope", "owner", "object_type")
+	assert.Error(t, err)
+	_, err = parseResourceID("object_privileges:app_user::table::current", "object_privileges", "principals:owner:object:object_type:container_scope")
+	assert.Error(t, err)
+}
+
+func TestParseImportID(t *testing.T) {
+	format := "principals:owner:object:object_type:container_scope"
+
+	parts, err := parseImportID("object_privileges:app_user:hr:employees:TABLE:all", "object_privileges", format, "principals:owner:object", "owner", "object_type", "container_scope")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app_user", "hr", "employees", "TABLE", "all"}, parts)
+
+	// Identifiers of older versions of the provider leave out the new fields
+	parts, err = parseImportID("app_user:hr:employees", "object_privileges", format, "principals:owner:object", "owner", "object_type", "container_scope")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app_user", "hr", "employees", "", ""}, parts)
+
+	_, err = parseImportID("app_user:hr", "object_privileges", format, "principals:owner:object", "owner", "object_type", "container_scope")
+	assert.Error(t, err)
+}
+
+func TestUpgradeResourceID(t *testing.T) {
+	ctx := context.Background()
+	r := &GrantRolesResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Version 0 identified grants by principal, version 1 also by roles
+	for version, priorID := range map[int64]string{0: "testuser", 1: "roles:testuser:connect,resource:current"} {
+		upgrader := r.UpgradeState(ctx)[version]
+		priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+		priorValues := map[string]tftypes.Value{}
+		for name, attributeType := range priorType.AttributeTypes {
+			priorValues[name] = tftypes.NewValue(attributeType, nil)
+		}
+		priorValues["id"] = tftypes.NewValue(tftypes.String, priorID)
+		priorValues["principal"] = tftypes.NewValue(tftypes.String, "testuser")
+		priorValues["roles"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "resource"),
+			tftypes.NewValue(tftypes.String, "connect"),
+		})
+
+		req := resource.UpgradeStateRequest{
+			State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: tftypes.NewValue(priorType, priorValues)},
+		}
+		resp := resource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}
+		upgrader.StateUpgrader(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data GrantRolesResourceModel
+		assert.False(t, resp.State.Get(ctx, &data).HasError())
+		assert.Equal(t, "roles:testuser:current:connect,resource", data.ID.ValueString())
+		assert.Equal(t, "testuser", data.Principal.ValueString())
+	}
+}
+
+func TestUpgradeSystemPrivilegesResourceID(t *testing.T) {
+	ctx := context.Background()
+	r := &GrantSystemPrivilegesResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Version 2 identified grants by principals and container scope only
+	upgrader := r.UpgradeState(ctx)[2]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+	priorValues := map[string]tftypes.Value{}
+	for name, attributeType := range priorType.AttributeTypes {
+		priorValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	privilegeType := priorType.AttributeTypes["privileges"].(tftypes.Set).ElementType.(tftypes.Object)
+	privilege := func(name string) tftypes.Value {
+		return tftypes.NewValue(privilegeType, map[string]tftypes.Value{
+			"privilege":         tftypes.NewValue(tftypes.String, name),
+			"with_admin_option": tftypes.NewValue(tftypes.Bool, false),
+		})
+	}
+	priorValues["id"] = tftypes.NewValue(tftypes.String, "system_privileges:testuser:current")
+	priorValues["principal"] = tftypes.NewValue(tftypes.String, "testuser")
+	priorValues["privileges"] = tftypes.NewValue(tftypes.Set{ElementType: privilegeType}, []tftypes.Value{
+		privilege("CREATE TABLE"),
+		privilege("CREATE SESSION"),
+	})
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: tftypes.NewValue(priorType, priorValues)},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data GrantSystemPrivilegesResourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+	assert.Equal(t, "system_privileges:testuser:current:CREATE SESSION,CREATE TABLE", data.ID.ValueString())
+}
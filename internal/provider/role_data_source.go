@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoleDataSource{}
+
+func NewRoleDataSource() datasource.DataSource {
+	return &RoleDataSource{}
+}
+
+// RoleDataSource defines the data source implementation.
+type RoleDataSource struct {
+	client *oracle.Client
+}
+
+// RoleDataSourceModel describes the data source data model.
+type RoleDataSourceModel struct {
+	Name               types.String `tfsdk:"name"`
+	AuthenticationType types.String `tfsdk:"authentication_type"`
+	ContainerScope     types.String `tfsdk:"container_scope"`
+	OracleMaintained   types.Bool   `tfsdk:"oracle_maintained"`
+	Common             types.Bool   `tfsdk:"common"`
+	Inherited          types.Bool   `tfsdk:"inherited"`
+	Implicit           types.Bool   `tfsdk:"implicit"`
+	SystemPrivileges   types.Set    `tfsdk:"system_privileges"`
+	ObjectPrivileges   types.Set    `tfsdk:"object_privileges"`
+	Roles              types.Set    `tfsdk:"roles"`
+	Grantees           types.Set    `tfsdk:"grantees"`
+	ID                 types.String `tfsdk:"id"`
+}
+
+// RoleObjectPrivilegeModel describes an object privilege granted to a role.
+type RoleObjectPrivilegeModel struct {
+	Owner     types.String `tfsdk:"owner"`
+	Object    types.String `tfsdk:"object"`
+	Privilege types.String `tfsdk:"privilege"`
+}
+
+// roleObjectPrivilegeType is the object type of the object_privileges elements.
+var roleObjectPrivilegeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"owner":     types.StringType,
+		"object":    types.StringType,
+		"privilege": types.StringType,
+	},
+}
+
+func (d *RoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing Oracle role together with its direct privileges and grantees, without managing it.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the role.",
+				Required:            true,
+			},
+			"authentication_type": schema.StringAttribute{
+				MarkdownDescription: "How the role is enabled: `none`, `password`, `application`, `external` or `global`.",
+				Computed:            true,
+			},
+			"container_scope": schema.StringAttribute{
+				MarkdownDescription: "The container scope of the role, either `current` or `all`.",
+				Computed:            true,
+			},
+			"oracle_maintained": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role was created by Oracle-maintained scripts.",
+				Computed:            true,
+			},
+			"common": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role is a common role in a multitenant container database.",
+				Computed:            true,
+			},
+			"inherited": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role definition was inherited from another container. Always `false` before Oracle 12.2.",
+				Computed:            true,
+			},
+			"implicit": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role is a common role created implicitly by an application container. Always `false` before Oracle 12.2.",
+				Computed:            true,
+			},
+			"system_privileges": schema.SetAttribute{
+				MarkdownDescription: "The system privileges granted directly to the role.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"object_privileges": schema.SetNestedAttribute{
+				MarkdownDescription: "The object privileges granted directly to the role.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"owner": schema.StringAttribute{
+							MarkdownDescription: "The owner of the object.",
+							Computed:            true,
+						},
+						"object": schema.StringAttribute{
+							MarkdownDescription: "The name of the object.",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "The privilege granted on the object.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "The roles granted directly to the role.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"grantees": schema.SetAttribute{
+				MarkdownDescription: "The users and roles the role is granted to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := d.client.ReadRole(data.Name.ValueString())
+	if errors.Is(err, sql.ErrNoRows) {
+		resp.Diagnostics.AddError("Role Not Found", fmt.Sprintf("The role %s does not exist.", data.Name.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return
+	}
+
+	privileges, err := d.client.ReadRolePrivileges(role.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role privileges, got error: %s", err))
+		return
+	}
+
+	objectPrivileges := make([]RoleObjectPrivilegeModel, 0, len(privileges.ObjectPrivileges))
+	for _, privilege := range privileges.ObjectPrivileges {
+		objectPrivileges = append(objectPrivileges, RoleObjectPrivilegeModel{
+			Owner:     types.StringValue(privilege.Owner),
+			Object:    types.StringValue(privilege.Object),
+			Privilege: types.StringValue(privilege.Privilege),
+		})
+	}
+
+	data.ID = types.StringValue(role.Name)
+	data.AuthenticationType = types.StringValue(role.AuthenticationType)
+	data.ContainerScope = types.StringValue(role.ContainerScope)
+	data.OracleMaintained = types.BoolValue(role.OracleMaintained)
+	data.Common = types.BoolValue(role.Common)
+	data.Inherited = types.BoolValue(role.Inherited)
+	data.Implicit = types.BoolValue(role.Implicit)
+
+	var diags diag.Diagnostics
+	data.SystemPrivileges, diags = types.SetValueFrom(ctx, types.StringType, privileges.SystemPrivileges)
+	resp.Diagnostics.Append(diags...)
+	data.ObjectPrivileges, diags = types.SetValueFrom(ctx, roleObjectPrivilegeType, objectPrivileges)
+	resp.Diagnostics.Append(diags...)
+	data.Roles, diags = types.SetValueFrom(ctx, types.StringType, privileges.Roles)
+	resp.Diagnostics.Append(diags...)
+	data.Grantees, diags = types.SetValueFrom(ctx, types.StringType, privileges.Grantees)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a role data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
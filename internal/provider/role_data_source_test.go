@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_RoleDataSource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read a managed role and its privileges
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name = "testrole_%s"
+}
+
+resource "oracle_grant_system_privileges" "test_grant" {
+  principal  = oracle_role.test_role.name
+  privileges = [{ privilege = "CREATE SESSION" }]
+}
+
+data "oracle_role" "test_role" {
+  name = oracle_grant_system_privileges.test_grant.principal
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.oracle_role.test_role", "id", strings.ToUpper(fmt.Sprintf("testrole_%s", randString))),
+					resource.TestCheckResourceAttr("data.oracle_role.test_role", "authentication_type", "none"),
+					resource.TestCheckResourceAttr("data.oracle_role.test_role", "oracle_maintained", "false"),
+					resource.TestCheckResourceAttr("data.oracle_role.test_role", "system_privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.oracle_role.test_role", "system_privileges.*", "CREATE SESSION"),
+					resource.TestCheckResourceAttr("data.oracle_role.test_role", "object_privileges.#", "0"),
+				),
+			},
+			// Read an Oracle-maintained role
+			{
+				Config: providerConfig + `
+data "oracle_role" "connect" {
+  name = "connect"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.oracle_role.connect", "oracle_maintained", "true"),
+					resource.TestCheckTypeSetElemAttr("data.oracle_role.connect", "system_privileges.*", "CREATE SESSION"),
+				),
+			},
+			// Unknown roles are an error
+			{
+				Config: providerConfig + `
+data "oracle_role" "missing" {
+  name = "missing_role"
+}
+`,
+				ExpectError: regexp.MustCompile(`Role Not Found`),
+			},
+		},
+	})
+}
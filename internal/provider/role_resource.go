@@ -6,13 +6,18 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -22,6 +27,7 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithValidateConfig = &RoleResource{}
 
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
@@ -34,8 +40,20 @@ type RoleResource struct {
 
 // RoleResourceModel describes the resource data model.
 type RoleResourceModel struct {
-	Name types.String `tfsdk:"name"`
-	ID   types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	ContainerScope     types.String `tfsdk:"container_scope"`
+	AuthenticationType types.String `tfsdk:"authentication_type"`
+	Password           types.String `tfsdk:"password"`
+	PasswordVersion    types.Int64  `tfsdk:"password_version"`
+	Package            types.String `tfsdk:"package"`
+	OracleMaintained   types.Bool   `tfsdk:"oracle_maintained"`
+	Common             types.Bool   `tfsdk:"common"`
+	Inherited          types.Bool   `tfsdk:"inherited"`
+	Implicit           types.Bool   `tfsdk:"implicit"`
+	SystemPrivileges   types.Int64  `tfsdk:"system_privilege_count"`
+	ObjectPrivileges   types.Int64  `tfsdk:"object_privilege_count"`
+	RolePrivileges     types.Int64  `tfsdk:"role_privilege_count"`
+	ID                 types.String `tfsdk:"id"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,8 +66,89 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "name of the role. Must be unique.",
+				MarkdownDescription: "name of the role. Must be unique. Changing this forces a new role to be created.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
+			},
+			"container_scope": containerScopeAttribute("The container scope of the role. Common roles must be prefixed with the `common_user_prefix` of the database (usually `C##`)."),
+			"authentication_type": schema.StringAttribute{
+				MarkdownDescription: "How the role is enabled. Possible values are `none`, `password` (`IDENTIFIED BY`), `application` (`IDENTIFIED USING`, a secure application role), " +
+					"`external` and `global`. If not specified, the default is `none`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("none"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "password", "application", "external", "global"),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of the role. Required when `authentication_type` is `password`. " +
+					"This is a write-only attribute that is never stored in state; change `password_version` to apply a new password.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_version": schema.Int64Attribute{
+				MarkdownDescription: "An arbitrary version of `password`. Changing this sets the role password again.",
+				Optional:            true,
+			},
+			"package": schema.StringAttribute{
+				MarkdownDescription: "The package that enables the role, in the form `schema.package`. Required when `authentication_type` is `application`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^.\s]+\.[^.\s]+$`), "must be in the form schema.package"),
+				},
+			},
+			"oracle_maintained": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role was created by Oracle-maintained scripts.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"common": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role is a common role in a multitenant container database.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherited": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role definition was inherited from another container. Always `false` before Oracle 12.2.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"implicit": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role is a common role created implicitly by an application container. Always `false` before Oracle 12.2.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"system_privilege_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of system privileges granted directly to the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_privilege_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of object privileges granted directly to the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_privilege_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of roles granted directly to the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -62,6 +161,48 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+func (r *RoleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.AuthenticationType.IsUnknown() {
+		return
+	}
+
+	authType := data.AuthenticationType.ValueString()
+
+	if authType == "password" && data.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Attribute Configuration",
+			"A password is required when authentication_type is \"password\".",
+		)
+	}
+	if authType != "password" && !data.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Conflicting Attribute Configuration",
+			"password can only be set when authentication_type is \"password\".",
+		)
+	}
+
+	if authType == "application" && data.Package.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("package"),
+			"Missing Attribute Configuration",
+			"A package is required when authentication_type is \"application\".",
+		)
+	}
+	if authType != "application" && !data.Package.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("package"),
+			"Conflicting Attribute Configuration",
+			"package can only be set when authentication_type is \"application\".",
+		)
+	}
+}
+
 func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -92,8 +233,25 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// The password is write-only and only available in the configuration
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	role := oracle.Role{
-		Name: data.Name.ValueString(),
+		Name:               data.Name.ValueString(),
+		ContainerScope:     data.ContainerScope.ValueString(),
+		AuthenticationType: data.AuthenticationType.ValueString(),
+		Password:           password.ValueString(),
+		Package:            data.Package.ValueString(),
+	}
+
+	if err := r.client.ValidateContainerName(role.Name, role.ContainerScope); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Role Name", err.Error())
+		return
 	}
 
 	err := r.client.CreateRole(role)
@@ -106,6 +264,12 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Trace(ctx, "created a role resource")
 
+	// Read back role details to populate computed fields
+	if err := r.readRole(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -125,27 +289,57 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	data.Name = types.StringValue(strings.ToLower(role.Name))
+	privileges, err := r.client.ReadRolePrivileges(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role privileges, got error: %s", err))
+		return
+	}
+
+	setRoleAttributes(&data, role, privileges)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan, state RoleResourceModel
+	var data, state RoleResourceModel
+	var password types.String
 
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if plan.Name != state.Name {
-		resp.Diagnostics.AddError(
-			"Attribute 'name' cannot be updated",
-			"The Oracle provider does not support updating the name of a role. Please create a new resource instead.",
-		)
+	// Name changes other than in case force a new role, so only the
+	// authentication of the role can change in place.
+	authType := data.AuthenticationType.ValueString()
+	if !data.AuthenticationType.Equal(state.AuthenticationType) ||
+		(authType == "application" && !data.Package.Equal(state.Package)) ||
+		(authType == "password" && !data.PasswordVersion.Equal(state.PasswordVersion)) {
+		role := oracle.Role{
+			Name:               data.Name.ValueString(),
+			ContainerScope:     data.ContainerScope.ValueString(),
+			AuthenticationType: authType,
+			Password:           password.ValueString(),
+			Package:            data.Package.ValueString(),
+		}
+
+		if err := r.client.ModifyRole(role); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update role, got error: %s", err))
+			return
+		}
+	}
+
+	// Read back role details to populate computed fields
+	if err := r.readRole(&data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return
 	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -165,5 +359,45 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := splitResourceID(req.ID, "name"); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// readRole reads a role and its privileges from the database and populates
+// the computed attributes of the model.
+func (r *RoleResource) readRole(data *RoleResourceModel) error {
+	role, err := r.client.ReadRole(data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	privileges, err := r.client.ReadRolePrivileges(data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+	setRoleAttributes(data, role, privileges)
+	return nil
+}
+
+// setRoleAttributes populates the model from a role and its privileges read
+// from the database.
+func setRoleAttributes(data *RoleResourceModel, role *oracle.Role, privileges *oracle.RolePrivileges) {
+	data.Name = identifierValue(data.Name, role.Name)
+	data.ContainerScope = types.StringValue(role.ContainerScope)
+	data.AuthenticationType = types.StringValue(role.AuthenticationType)
+	if role.Package == "" {
+		data.Package = types.StringNull()
+	} else {
+		data.Package = identifierValue(data.Package, role.Package)
+	}
+	data.OracleMaintained = types.BoolValue(role.OracleMaintained)
+	data.Common = types.BoolValue(role.Common)
+	data.Inherited = types.BoolValue(role.Inherited)
+	data.Implicit = types.BoolValue(role.Implicit)
+	data.SystemPrivileges = types.Int64Value(int64(len(privileges.SystemPrivileges)))
+	data.ObjectPrivileges = types.Int64Value(int64(len(privileges.ObjectPrivileges)))
+	data.RolePrivileges = types.Int64Value(int64(len(privileges.Roles)))
+}
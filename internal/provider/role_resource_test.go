@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -26,6 +27,12 @@ resource "oracle_role" "test_role" {
 `, randString),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("oracle_role.test_role", "name", fmt.Sprintf("testrole_%s", randString)),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "container_scope", "current"),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "oracle_maintained", "false"),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "common", "false"),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "system_privilege_count", "0"),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "object_privilege_count", "0"),
+					resource.TestCheckResourceAttr("oracle_role.test_role", "role_privilege_count", "0"),
 				),
 			},
 			// ImportState testing
@@ -34,7 +41,84 @@ resource "oracle_role" "test_role" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:    "oracle_role.test_role",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAcc_RoleResource_Authentication(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A password requires password authentication
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name     = "testrole_%s"
+  password = "password"
+}
+`, randString),
+				ExpectError: regexp.MustCompile(`password can only be set`),
+			},
+			// Create a password-protected role
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name                = "testrole_%s"
+  authentication_type = "password"
+  password            = "password"
+  password_version    = 1
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_role.test_role", "authentication_type", "password"),
+					resource.TestCheckNoResourceAttr("oracle_role.test_role", "password"),
+				),
+			},
+			// Rotate the password
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name                = "testrole_%s"
+  authentication_type = "password"
+  password            = "new_password"
+  password_version    = 2
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_role.test_role", "password_version", "2"),
+				),
+			},
+			// Switch to an externally identified role in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name                = "testrole_%s"
+  authentication_type = "external"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_role.test_role", "authentication_type", "external"),
+				),
+			},
+			// Remove the authentication again
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_role" "test_role" {
+  name = "testrole_%s"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_role.test_role", "authentication_type", "none"),
+				),
+			},
+		},
+	})
+}
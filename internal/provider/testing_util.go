@@ -9,7 +9,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	_ "github.com/sijms/go-ora/v2"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
 )
 
 const (
@@ -100,6 +103,69 @@ func tearDownTestTableForOwner(t *testing.T, tableName, owner string) {
 	tearDownTestUser(t, owner)
 }
 
+// testAccCheckNoGrants verifies that a dictionary view holds no rows for a grantee.
+func testAccCheckNoGrants(view, grantee string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		db, err := getTestDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var count int
+		err = db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE grantee = UPPER(:1)", view), grantee).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("expected no rows in %s for %s, got %d", view, grantee, count)
+		}
+		return nil
+	}
+}
+
+// testAccCheckSystemPrivilege verifies that a system privilege is granted to a grantee.
+func testAccCheckSystemPrivilege(grantee, privilege string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		db, err := getTestDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM dba_sys_privs WHERE grantee = UPPER(:1) AND privilege = UPPER(:2)", grantee, privilege).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("expected %s to be granted %s", grantee, privilege)
+		}
+		return nil
+	}
+}
+
+// testAccCheckNoSystemPrivilege verifies that a system privilege is not granted to a grantee.
+func testAccCheckNoSystemPrivilege(grantee, privilege string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		db, err := getTestDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM dba_sys_privs WHERE grantee = UPPER(:1) AND privilege = UPPER(:2)", grantee, privilege).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("expected %s not to be granted %s", grantee, privilege)
+		}
+		return nil
+	}
+}
+
 func getTestDB() (*sql.DB, error) {
 	return sql.Open("oracle", getDBConnectionString())
 }
@@ -112,3 +178,25 @@ func getDBConnectionString() string {
 		os.Getenv("ORACLE_PORT"),
 		os.Getenv("ORACLE_SERVICE"))
 }
+
+// skipUnlessVersion skips the test if the test database is older than the given major release.
+func skipUnlessVersion(t *testing.T, major int) {
+	db, err := getTestDB()
+	if err != nil {
+		t.Fatalf("Error connecting to database: %s", err)
+	}
+	defer db.Close()
+
+	var version string
+	err = db.QueryRow("SELECT version FROM product_component_version WHERE product LIKE 'Oracle Database%' AND ROWNUM = 1").Scan(&version)
+	if err != nil {
+		t.Fatalf("Error reading database version: %s", err)
+	}
+	parsed, err := oracle.ParseVersion(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.AtLeast(major, 0) {
+		t.Skipf("test requires Oracle %d or later, the database version is %s", major, parsed)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserPasswordVerifierDataSource{}
+
+func NewUserPasswordVerifierDataSource() datasource.DataSource {
+	return &UserPasswordVerifierDataSource{}
+}
+
+// UserPasswordVerifierDataSource defines the data source implementation.
+type UserPasswordVerifierDataSource struct {
+	client *oracle.Client
+}
+
+// UserPasswordVerifierDataSourceModel describes the data source data model.
+type UserPasswordVerifierDataSourceModel struct {
+	Username       types.String `tfsdk:"username"`
+	Verifier       types.String `tfsdk:"verifier"`
+	SHA1Verifier   types.String `tfsdk:"sha1_verifier"`
+	SHA512Verifier types.String `tfsdk:"sha512_verifier"`
+	ID             types.String `tfsdk:"id"`
+}
+
+func (d *UserPasswordVerifierDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_password_verifier"
+}
+
+func (d *UserPasswordVerifierDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the password verifiers of an Oracle Database user from `sys.user$`, so that the user can be recreated in another database " +
+			"with the `password_hash` attribute of `oracle_user`. The provider user requires `SELECT` on `sys.user$`.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The name of the user.",
+				Required:            true,
+			},
+			"verifier": schema.StringAttribute{
+				MarkdownDescription: "The `S:` and `T:` verifiers of the user joined with `;`, usable with `IDENTIFIED BY VALUES`.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sha1_verifier": schema.StringAttribute{
+				MarkdownDescription: "The 11g `S:` verifier of the user, if any.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sha512_verifier": schema.StringAttribute{
+				MarkdownDescription: "The 12c `T:` verifier of the user, if any.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "User identifier",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserPasswordVerifierDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserPasswordVerifierDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserPasswordVerifierDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	verifier, err := d.client.ReadPasswordVerifier(data.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read password verifier, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(verifier.Username)
+	data.Verifier = types.StringValue(verifier.Verifier)
+	data.SHA1Verifier = optionalString(verifier.SHA1)
+	data.SHA512Verifier = optionalString(verifier.SHA512)
+
+	tflog.Trace(ctx, "read a user password verifier data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_UserPasswordVerifierDataSource(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Copy the password verifier of one user to another
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "source" {
+  username = "testuser_%[1]s"
+  password = "password"
+}
+
+data "oracle_user_password_verifier" "source" {
+  username = oracle_user.source.username
+}
+
+resource "oracle_user" "copy" {
+  username      = "testcopy_%[1]s"
+  password_hash = data.oracle_user_password_verifier.source.verifier
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.oracle_user_password_verifier.source", "verifier", regexp.MustCompile(`^S:[0-9A-F]{60}|T:[0-9A-F]{160}`)),
+					resource.TestCheckResourceAttrPair("data.oracle_user_password_verifier.source", "verifier", "oracle_user.copy", "password_hash"),
+					resource.TestCheckResourceAttr("oracle_user.copy", "authentication_type", "password"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_UserResource_InvalidPasswordHash(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username      = "testuser_%s"
+  password_hash = "S:1234"
+}
+`, randString),
+				ExpectError: regexp.MustCompile(`Invalid Password Verifier`),
+			},
+		},
+	})
+}
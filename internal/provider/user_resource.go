@@ -5,14 +5,21 @@ package provider
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -22,6 +29,8 @@ import (
 // Ensure provider-defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -36,11 +45,23 @@ type UserResource struct {
 type UserResourceModel struct {
 	Username              types.String `tfsdk:"username"`
 	Password              types.String `tfsdk:"password"`
+	PasswordHash          types.String `tfsdk:"password_hash"`
 	DefaultTablespace     types.String `tfsdk:"default_tablespace"`
 	DefaultTempTablespace types.String `tfsdk:"default_temp_tablespace"`
 	Profile               types.String `tfsdk:"profile"`
 	AuthenticationType    types.String `tfsdk:"authentication_type"`
+	Locked                types.Bool   `tfsdk:"locked"`
 	State                 types.String `tfsdk:"state"`
+	ExternalName          types.String `tfsdk:"external_name"`
+	ContainerScope        types.String `tfsdk:"container_scope"`
+	LocalTempTablespace   types.String `tfsdk:"local_temp_tablespace"`
+	EditionsEnabled       types.Bool   `tfsdk:"editions_enabled"`
+	Expired               types.Bool   `tfsdk:"expired"`
+	LockDate              types.String `tfsdk:"lock_date"`
+	ExpiryDate            types.String `tfsdk:"expiry_date"`
+	RawAccountStatus      types.String `tfsdk:"raw_account_status"`
+	DropBehavior          types.String `tfsdk:"drop_behavior"`
+	KillSessionsOnDelete  types.Bool   `tfsdk:"kill_sessions_on_delete"`
 	ID                    types.String `tfsdk:"id"`
 }
 
@@ -54,14 +75,24 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				MarkdownDescription: "The name of the user to create.(This should be specified in lowercase. For example: `test_user`).",
+				MarkdownDescription: "The name of the user to create.(This should be specified in lowercase. For example: `test_user`). Changing this forces a new user to be created.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfIdentifierChanged(),
+				},
 			},
 			"password": schema.StringAttribute{
 				MarkdownDescription: "The password for the user. This is a sensitive attribute.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"password_hash": schema.StringAttribute{
+				MarkdownDescription: "An existing password verifier for the user (`IDENTIFIED BY VALUES`), e.g. `S:...;T:...` as returned by the " +
+					"`oracle_user_password_verifier` data source. Use this to migrate users between databases without knowing their password. " +
+					"Conflicts with `password`. This is a sensitive attribute.",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"default_tablespace": schema.StringAttribute{
 				MarkdownDescription: "The default tablespace for the user.",
 				Optional:            true,
@@ -72,20 +103,84 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 			},
+			"local_temp_tablespace": schema.StringAttribute{
+				MarkdownDescription: "The local temporary tablespace for the user, used by read-only instances and RAC. Requires Oracle 18c or later.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"editions_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can own editionable objects for edition-based redefinition (`ENABLE EDITIONS`). " +
+					"Editions cannot be disabled once they are enabled.",
+				Optional: true,
+				Computed: true,
+			},
 			"profile": schema.StringAttribute{
 				MarkdownDescription: "The profile assigned to the user.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"authentication_type": schema.StringAttribute{
-				MarkdownDescription: "The authentication method for the user (e.g., `PASSWORD`, `EXTERNAL`, `GLOBAL`).",
+				MarkdownDescription: "The authentication method for the user. Possible values are `password`, `external`, `global` and `none`. " +
+					"Use `none` for schema-only accounts that cannot log in (Oracle 18c and later). If not specified, the default is `password`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("password", "external", "global", "none"),
+				},
+			},
+			"external_name": schema.StringAttribute{
+				MarkdownDescription: "The external identity of the user. For `external` users this is the Kerberos principal or certificate DN " +
+					"(`IDENTIFIED EXTERNALLY AS`), for `global` users the directory DN used by Enterprise User Security (`IDENTIFIED GLOBALLY AS`).",
+				Optional: true,
+			},
+			"container_scope": containerScopeAttribute("The container scope of the user. Common users must be prefixed with the `common_user_prefix` of the database (usually `C##`)."),
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is locked with `ACCOUNT LOCK`. Temporary locks caused by failed login attempts (`LOCKED(TIMED)`) are not reported here.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"state": schema.StringAttribute{
-				MarkdownDescription: "The account state of the user (e.g., `OPEN`, `LOCKED`, `EXPIRED`).",
+				MarkdownDescription: "**Deprecated**, use `locked` instead. Whether the account is `locked` or `unlocked`. Conflicts with `locked`.",
+				DeprecationMessage:  "Use locked instead. The state attribute will be removed in the next major release.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("locked", "unlocked"),
+					stringvalidator.ConflictsWith(path.MatchRoot("locked")),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the password of the user has expired. Passwords in their grace period are not considered expired.",
+				Computed:            true,
+			},
+			"lock_date": schema.StringAttribute{
+				MarkdownDescription: "The date the account was locked, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"expiry_date": schema.StringAttribute{
+				MarkdownDescription: "The date the password of the user expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"raw_account_status": schema.StringAttribute{
+				MarkdownDescription: "The account status as reported by `dba_users.account_status` (e.g., `OPEN`, `EXPIRED & LOCKED(TIMED)`).",
+				Computed:            true,
+			},
+			"drop_behavior": schema.StringAttribute{
+				MarkdownDescription: "What happens to the user when the resource is destroyed. `cascade` drops the user together with all of its objects, " +
+					"`restrict` drops the user only if it owns no objects, and `lock_only` locks the account and expires its password instead of dropping it. " +
+					"If not specified, the default is `cascade`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("cascade"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("restrict", "cascade", "lock_only"),
+				},
+			},
+			"kill_sessions_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether to terminate the sessions of the user before it is dropped. Without this, dropping a connected user fails with `ORA-01940`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -98,6 +193,117 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+func (r *UserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data UserResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.AuthenticationType.ValueString() == "none" && !data.Password.IsNull() && !data.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Conflicting Attribute Configuration",
+			"A password cannot be set when authentication_type is \"none\". Schema-only accounts cannot log in.",
+		)
+	}
+
+	if !data.PasswordHash.IsNull() && !data.PasswordHash.IsUnknown() {
+		if !data.Password.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_hash"),
+				"Conflicting Attribute Configuration",
+				"password and password_hash cannot both be set.",
+			)
+		}
+		switch data.AuthenticationType.ValueString() {
+		case "", "password":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_hash"),
+				"Conflicting Attribute Configuration",
+				"password_hash can only be set when authentication_type is \"password\".",
+			)
+		}
+		if err := oracle.ValidatePasswordVerifier(data.PasswordHash.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("password_hash"), "Invalid Password Verifier", err.Error())
+		}
+	}
+
+	if !data.ExternalName.IsNull() && !data.AuthenticationType.IsUnknown() {
+		switch data.AuthenticationType.ValueString() {
+		case "external", "global":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("external_name"),
+				"Conflicting Attribute Configuration",
+				"external_name can only be set when authentication_type is \"external\" or \"global\".",
+			)
+		}
+	}
+}
+
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed or the provider is not configured yet.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan UserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state UserResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.EditionsEnabled.ValueBool() && !plan.EditionsEnabled.IsUnknown() && !plan.EditionsEnabled.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("editions_enabled"),
+				"Editions Cannot Be Disabled",
+				"Editions are enabled for this user and cannot be disabled. Remove editions_enabled from the configuration or set it to true.",
+			)
+		}
+	}
+
+	localTemp := !plan.LocalTempTablespace.IsNull() && !plan.LocalTempTablespace.IsUnknown()
+	editions := plan.EditionsEnabled.ValueBool()
+	if !localTemp && !editions {
+		return
+	}
+
+	version, err := r.client.DatabaseVersion()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database version, got error: %s", err))
+		return
+	}
+
+	if localTemp && !version.AtLeast(18, 0) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("local_temp_tablespace"),
+			"Unsupported Attribute",
+			fmt.Sprintf("Local temporary tablespaces require Oracle 18c or later, the database version is %s.", version),
+		)
+	}
+
+	if editions && !version.AtLeast(11, 2) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("editions_enabled"),
+			"Unsupported Attribute",
+			fmt.Sprintf("Edition-based redefinition requires Oracle 11g Release 2 or later, the database version is %s.", version),
+		)
+	}
+}
+
 func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -135,11 +341,21 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	user := oracle.User{
 		Username:              data.Username.ValueString(),
 		Password:              data.Password.ValueString(),
+		PasswordHash:          data.PasswordHash.ValueString(),
 		DefaultTablespace:     data.DefaultTablespace.ValueString(),
 		DefaultTempTablespace: data.DefaultTempTablespace.ValueString(),
 		Profile:               data.Profile.ValueString(),
 		AuthenticationType:    data.AuthenticationType.ValueString(),
-		State:                 data.State.ValueString(),
+		State:                 lockState(data.Locked, data.State),
+		ExternalName:          data.ExternalName.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		LocalTempTablespace:   data.LocalTempTablespace.ValueString(),
+		EditionsEnabled:       data.EditionsEnabled.ValueBool(),
+	}
+
+	if err := r.client.ValidateContainerName(user.Username, user.ContainerScope); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("username"), "Invalid Username", err.Error())
+		return
 	}
 
 	err := r.client.CreateUser(user)
@@ -163,7 +379,10 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.DefaultTempTablespace = types.StringValue(createdUser.DefaultTempTablespace)
 	data.Profile = types.StringValue(createdUser.Profile)
 	data.AuthenticationType = types.StringValue(createdUser.AuthenticationType)
-	data.State = types.StringValue(createdUser.State)
+	data.ExternalName = optionalString(createdUser.ExternalName)
+	data.LocalTempTablespace = optionalString(createdUser.LocalTempTablespace)
+	data.EditionsEnabled = types.BoolValue(createdUser.EditionsEnabled)
+	setAccountStatus(&data, createdUser)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -178,27 +397,42 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	user, err := r.client.ReadUser(data.ID.ValueString())
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		// If the user is not found, remove it from state
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
 
-	data.Username = types.StringValue(strings.ToLower(user.Username))
+	data.Username = identifierValue(data.Username, user.Username)
+	data.ContainerScope = types.StringValue(user.ContainerScope)
+	if data.DropBehavior.IsNull() {
+		data.DropBehavior = types.StringValue("cascade")
+	}
+	if data.KillSessionsOnDelete.IsNull() {
+		data.KillSessionsOnDelete = types.BoolValue(false)
+	}
 	data.DefaultTablespace = types.StringValue(user.DefaultTablespace)
 	data.DefaultTempTablespace = types.StringValue(user.DefaultTempTablespace)
 	data.Profile = types.StringValue(user.Profile)
 	data.AuthenticationType = types.StringValue(user.AuthenticationType)
-	data.State = types.StringValue(user.State)
+	data.ExternalName = optionalString(user.ExternalName)
+	data.LocalTempTablespace = optionalString(user.LocalTempTablespace)
+	data.EditionsEnabled = types.BoolValue(user.EditionsEnabled)
+	setAccountStatus(&data, user)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data UserResourceModel
+	var data, state UserResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -211,7 +445,15 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		DefaultTempTablespace: data.DefaultTempTablespace.ValueString(),
 		Profile:               data.Profile.ValueString(),
 		AuthenticationType:    data.AuthenticationType.ValueString(),
-		State:                 data.State.ValueString(),
+		State:                 lockState(data.Locked, data.State),
+		ExternalName:          data.ExternalName.ValueString(),
+		ContainerScope:        data.ContainerScope.ValueString(),
+		LocalTempTablespace:   data.LocalTempTablespace.ValueString(),
+		// The verifier is only applied again when it changed, so that password
+		// changes made outside of Terraform are not reverted on every update
+		PasswordHash: changedString(data.PasswordHash, state.PasswordHash),
+		// ENABLE EDITIONS is only issued when editions are being enabled
+		EditionsEnabled: data.EditionsEnabled.ValueBool() && !state.EditionsEnabled.ValueBool(),
 	}
 
 	err := r.client.ModifyUser(user)
@@ -231,7 +473,10 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.DefaultTempTablespace = types.StringValue(updatedUser.DefaultTempTablespace)
 	data.Profile = types.StringValue(updatedUser.Profile)
 	data.AuthenticationType = types.StringValue(updatedUser.AuthenticationType)
-	data.State = types.StringValue(updatedUser.State)
+	data.ExternalName = optionalString(updatedUser.ExternalName)
+	data.LocalTempTablespace = optionalString(updatedUser.LocalTempTablespace)
+	data.EditionsEnabled = types.BoolValue(updatedUser.EditionsEnabled)
+	setAccountStatus(&data, updatedUser)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -245,7 +490,24 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DropUser(data.Username.ValueString())
+	username := data.Username.ValueString()
+
+	if data.KillSessionsOnDelete.ValueBool() {
+		if err := r.client.KillUserSessions(username); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to kill user sessions, got error: %s", err))
+			return
+		}
+	}
+
+	var err error
+	switch data.DropBehavior.ValueString() {
+	case "lock_only":
+		err = r.client.LockAndExpireUser(username)
+	case "restrict":
+		err = r.client.DropUser(username, false)
+	default:
+		err = r.client.DropUser(username, true)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to drop user, got error: %s", err))
 		return
@@ -253,5 +515,66 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := splitResourceID(req.ID, "username"); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// lockState maps the locked attribute, or the deprecated state attribute if
+// locked is not set, to the state expected by the oracle package. Unknown or
+// null values leave the lock state unchanged.
+func lockState(locked types.Bool, state types.String) string {
+	if !locked.IsNull() && !locked.IsUnknown() {
+		if locked.ValueBool() {
+			return "locked"
+		}
+		return "unlocked"
+	}
+	if !state.IsNull() && !state.IsUnknown() {
+		return strings.ToLower(state.ValueString())
+	}
+	return ""
+}
+
+// setAccountStatus populates the account status attributes of the model from
+// a user read from the database.
+func setAccountStatus(data *UserResourceModel, user *oracle.User) {
+	data.Locked = types.BoolValue(user.State == "locked")
+	// Keep the case of the deprecated state attribute from the configuration
+	if !strings.EqualFold(data.State.ValueString(), user.State) {
+		data.State = types.StringValue(user.State)
+	}
+	data.Expired = types.BoolValue(user.Expired)
+	data.LockDate = optionalTime(user.LockDate)
+	data.ExpiryDate = optionalTime(user.ExpiryDate)
+	data.RawAccountStatus = types.StringValue(user.RawAccountStatus)
+}
+
+// changedString returns the planned value if it differs from the prior state,
+// or an empty string if it is unchanged.
+func changedString(plan, state types.String) string {
+	if plan.Equal(state) {
+		return ""
+	}
+	return plan.ValueString()
+}
+
+// optionalTime returns an RFC 3339 string value, or null for the zero time.
+func optionalTime(value time.Time) types.String {
+	if value.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(value.Format(time.RFC3339))
+}
+
+// optionalString returns a null string value for empty strings so that
+// optional attributes which are not set in Oracle stay null in state.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -5,10 +5,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAcc_UserResource(t *testing.T) {
@@ -32,7 +34,12 @@ resource "oracle_user" "test_user" {
 					resource.TestCheckResourceAttrSet("oracle_user.test_user", "default_temp_tablespace"),
 					resource.TestCheckResourceAttrSet("oracle_user.test_user", "profile"),
 					resource.TestCheckResourceAttrSet("oracle_user.test_user", "authentication_type"),
-					resource.TestCheckResourceAttrSet("oracle_user.test_user", "state"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "container_scope", "current"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "drop_behavior", "cascade"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "locked", "false"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "expired", "false"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "raw_account_status", "OPEN"),
+					resource.TestCheckResourceAttrSet("oracle_user.test_user", "expiry_date"),
 				),
 			},
 			// ImportState testing
@@ -54,7 +61,266 @@ resource "oracle_user" "test_user" {
 					resource.TestCheckResourceAttr("oracle_user.test_user", "password", "newpassword"),
 				),
 			},
+			// Explicit profile and tablespace survive the import
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username           = "testuser_%s"
+  password           = "newpassword"
+  profile            = "DEFAULT"
+  default_tablespace = "USERS"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "profile", "DEFAULT"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "default_tablespace", "USERS"),
+				),
+			},
+			{
+				ResourceName:            "oracle_user.test_user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported user, got %d", len(states))
+					}
+					for attribute, expected := range map[string]string{"profile": "DEFAULT", "default_tablespace": "USERS"} {
+						if actual := states[0].Attributes[attribute]; actual != expected {
+							return fmt.Errorf("expected imported %s to be %q, got %q", attribute, expected, actual)
+						}
+					}
+					return nil
+				},
+			},
+			// Lock the account
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "testuser_%s"
+  password = "newpassword"
+  locked   = true
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "locked", "true"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "raw_account_status", "LOCKED"),
+					resource.TestCheckResourceAttrSet("oracle_user.test_user", "lock_date"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAcc_UserResource_NoAuthentication(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a schema-only account
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%s"
+  authentication_type = "none"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "authentication_type", "none"),
+				),
+			},
+			// Switch to password authentication in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%s"
+  password            = "password"
+  authentication_type = "password"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "authentication_type", "password"),
+				),
+			},
+			// Switch back to a schema-only account
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%s"
+  authentication_type = "none"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "authentication_type", "none"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_UserResource_ExternalName(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create an externally identified user
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%[1]s"
+  authentication_type = "external"
+  external_name       = "testuser_%[1]s@EXAMPLE.COM"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "authentication_type", "external"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "external_name", fmt.Sprintf("testuser_%s@EXAMPLE.COM", randString)),
+				),
+			},
+			// Change the external name in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%[1]s"
+  authentication_type = "external"
+  external_name       = "testuser_%[1]s@CORP.EXAMPLE.COM"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "external_name", fmt.Sprintf("testuser_%s@CORP.EXAMPLE.COM", randString)),
+				),
+			},
+			// Switch to a global user identified by a directory DN
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username            = "testuser_%[1]s"
+  authentication_type = "global"
+  external_name       = "CN=testuser_%[1]s,OU=Users,DC=example,DC=com"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "authentication_type", "global"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "external_name", fmt.Sprintf("CN=testuser_%s,OU=Users,DC=example,DC=com", randString)),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_UserResource_ContainerScope(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Local users must not use the common user prefix
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username = "c##testuser_%s"
+  password = "password"
+}
+`, randString),
+				ExpectError: regexp.MustCompile(`must not start with`),
+			},
+		},
+	})
+}
+
+func TestAcc_UserResource_DropBehaviorLockOnly(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	username := "testuser_" + randString
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			db, err := getTestDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var status string
+			err = db.QueryRow("SELECT account_status FROM dba_users WHERE username = UPPER(:1)", username).Scan(&status)
+			if err != nil {
+				return fmt.Errorf("expected user %s to be kept, got error: %w", username, err)
+			}
+			if status != "EXPIRED & LOCKED" {
+				return fmt.Errorf("expected user %s to be locked and expired, got %q", username, status)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username                = %q
+  password                = "password"
+  drop_behavior           = "lock_only"
+  kill_sessions_on_delete = true
+}
+`, username),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "drop_behavior", "lock_only"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "kill_sessions_on_delete", "true"),
+				),
+			},
+		},
+	})
+	t.Cleanup(func() {
+		tearDownTestUser(t, username)
+	})
+}
+
+func TestAcc_UserResource_LocalTempTablespaceAndEditions(t *testing.T) {
+	randString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username              = "testuser_%s"
+  password              = "password"
+  local_temp_tablespace = "TEMP"
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "local_temp_tablespace", "TEMP"),
+					resource.TestCheckResourceAttr("oracle_user.test_user", "editions_enabled", "false"),
+				),
+			},
+			// Enable editions in place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username              = "testuser_%s"
+  password              = "password"
+  local_temp_tablespace = "TEMP"
+  editions_enabled      = true
+}
+`, randString),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("oracle_user.test_user", "editions_enabled", "true"),
+				),
+			},
+			// Editions cannot be disabled again
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "oracle_user" "test_user" {
+  username              = "testuser_%s"
+  password              = "password"
+  local_temp_tablespace = "TEMP"
+  editions_enabled      = false
+}
+`, randString),
+				ExpectError: regexp.MustCompile(`Editions Cannot Be Disabled`),
+			},
+		},
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neozocloud/terraform-provider-oracle/internal/oracle"
+)
+
+// Ensure provider-defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WalletACLResource{}
+var _ resource.ResourceWithImportState = &WalletACLResource{}
+
+// walletACLKind is the kind of the wallet ACL identifiers.
+const walletACLKind = "wallet_acl"
+
+func NewWalletACLResource() resource.Resource {
+	return &WalletACLResource{}
+}
+
+// WalletACLResource defines the resource implementation.
+type WalletACLResource struct {
+	client *oracle.Client
+}
+
+// WalletACLResourceModel describes the resource data model.
+type WalletACLResourceModel struct {
+	WalletPath    types.String `tfsdk:"wallet_path"`
+	Principal     types.String `tfsdk:"principal"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+	Privileges    types.Set    `tfsdk:"privileges"`
+	ID            types.String `tfsdk:"id"`
+}
+
+func (r *WalletACLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wallet_acl"
+}
+
+func (r *WalletACLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A resource to manage the privileges of a user or role on a wallet, such as the client certificates and passwords `UTL_HTTP` uses for authentication. The privileges are granted as a wallet access control entry with `DBMS_NETWORK_ACL_ADMIN`.",
+
+		Attributes: map[string]schema.Attribute{
+			"wallet_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the wallet, e.g. `file:/u01/app/oracle/wallet`. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal":      aclPrincipalAttribute(),
+			"principal_type": aclPrincipalTypeAttribute(),
+			"privileges": schema.SetAttribute{
+				MarkdownDescription: "The wallet privileges to grant to the principal: `use_client_certificates` or `use_passwords`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("use_client_certificates", "use_passwords")),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Wallet ACL identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *WalletACLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*oracle.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *oracle.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WalletACLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WalletACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace := data.walletACE()
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &ace.Privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AppendWalletACE(ace)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to append wallet ACE, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(formatResourceID(walletACLKind, ace.WalletPath, ace.Principal, ace.PrincipalType))
+
+	tflog.Trace(ctx, "created a wallet ACL resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WalletACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WalletACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, err := r.client.GetWalletACEPrivileges(data.walletACE())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read wallet ACE, got error: %s", err))
+		return
+	}
+	if len(privileges) == 0 {
+		// If the ACE is not found, remove it from the state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	privilegesValue, diags := types.SetValueFrom(ctx, types.StringType, privileges)
+	resp.Diagnostics.Append(diags...)
+	data.Privileges = privilegesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WalletACLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state WalletACLResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, prior []string
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the privileges are updated in place
+	added, removed := aclPrivilegeChanges(prior, planned)
+	ace := data.walletACE()
+	if len(added) > 0 {
+		ace.Privileges = added
+		if err := r.client.AppendWalletACE(ace); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to append wallet ACE, got error: %s", err))
+			return
+		}
+	}
+	if len(removed) > 0 {
+		ace.Privileges = removed
+		if err := r.client.RemoveWalletACE(ace); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove wallet ACE, got error: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WalletACLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WalletACLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ace := data.walletACE()
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &ace.Privileges, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveWalletACE(ace)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove wallet ACE, got error: %s", err))
+		return
+	}
+}
+
+func (r *WalletACLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseResourceID(req.ID, walletACLKind, "wallet_path:principal:principal_type")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wallet_path"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_type"), parts[2])...)
+}
+
+// walletACE returns the wallet ACE identified by the model, without privileges.
+func (data WalletACLResourceModel) walletACE() oracle.WalletACE {
+	return oracle.WalletACE{
+		WalletPath:    data.WalletPath.ValueString(),
+		Principal:     data.Principal.ValueString(),
+		PrincipalType: data.PrincipalType.ValueString(),
+	}
+}